@@ -0,0 +1,191 @@
+package v1alpha1
+
+import (
+    "fmt"
+
+    corev1 "k8s.io/api/core/v1"
+    "k8s.io/apimachinery/pkg/api/resource"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/runtime"
+)
+
+// MySQLSpec defines the desired state of MySQL
+type MySQLSpec struct {
+    DatabaseName   string `json:"databaseName"`
+    DatabaseUser   string `json:"databaseUser"`
+    BackupSchedule string `json:"backupSchedule,omitempty"`
+    MysqlVersion   string `json:"mysqlVersion,omitempty"`
+
+    // Engine selects the relational database engine this cluster runs.
+    // Defaults to "mysql" when unset, preserving the original API's behavior.
+    // +kubebuilder:validation:Enum=mysql;mariadb;postgres;mssql
+    Engine string `json:"engine,omitempty"`
+
+    // EngineVersion pins the engine's image tag. Falls back to MysqlVersion
+    // when unset, for backwards compatibility with the mysql-only API.
+    EngineVersion string `json:"engineVersion,omitempty"`
+
+    // EngineConfig carries engine-specific configuration that doesn't fit a
+    // common field. It is stored on the object as given; no engine Provider
+    // reads it.
+    EngineConfig *runtime.RawExtension `json:"engineConfig,omitempty"`
+
+    // SecretRef references a pre-existing Secret holding MySQL credentials.
+    // When set, the operator does not create or own a Secret and instead
+    // validates the referenced Secret contains the keys it needs. Mutually
+    // exclusive with relying on GenerateSecret.
+    SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+    // GenerateSecret causes the operator to generate a cryptographically
+    // random password on first reconcile when SecretRef is unset. Ignored
+    // if SecretRef is set.
+    GenerateSecret *bool `json:"generateSecret,omitempty"`
+
+    // Replicas is the desired number of MySQL pods. A value greater than 1
+    // switches on the selected TopologyMode's replication bootstrap.
+    // Defaults to 1 (standalone).
+    Replicas *int32 `json:"replicas,omitempty"`
+
+    // TopologyMode selects how replicas coordinate. One of "standalone",
+    // "semisync" or "groupreplication". Ignored when Replicas is unset or 1.
+    // "semisync" and "groupreplication" both bootstrap the same asynchronous
+    // source-replica replication; neither semi-synchronous replication nor
+    // Group Replication is configured.
+    // +kubebuilder:validation:Enum=standalone;semisync;groupreplication
+    TopologyMode string `json:"topologyMode,omitempty"`
+
+    // StorageSize is the requested size of each pod's data PVC. Immutable
+    // once bound except for online expansion, which the operator performs
+    // in place when the StorageClass allows it.
+    StorageSize resource.Quantity `json:"storageSize,omitempty"`
+
+    // StorageClassName selects the StorageClass backing the data PVC. Nil
+    // uses the cluster's default StorageClass.
+    StorageClassName *string `json:"storageClassName,omitempty"`
+
+    // Resources sets compute resource requests/limits on the mysql container.
+    Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+    // NodeSelector constrains which nodes MySQL pods may be scheduled to.
+    NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+    // Tolerations allows MySQL pods to schedule onto tainted nodes.
+    Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+    // Affinity applies node/pod (anti-)affinity rules to MySQL pods.
+    Affinity *corev1.Affinity `json:"affinity,omitempty"`
+}
+
+// MySQLStatus defines the observed state of MySQL
+type MySQLStatus struct {
+    Phase      string `json:"phase,omitempty"`
+    Message    string `json:"message,omitempty"`
+    LastBackup string `json:"lastBackup,omitempty"`
+
+    // PrimaryPod is the name of the pod currently accepting writes. Only
+    // populated in HA topology modes.
+    PrimaryPod string `json:"primaryPod,omitempty"`
+
+    // ReadyReplicas is the number of MySQL pods currently passing their
+    // readiness probe.
+    ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+    // PVCName is the name of the primary (ordinal-0) pod's data PVC.
+    PVCName string `json:"pvcName,omitempty"`
+
+    // Conditions are the latest observations of the cluster's state, keyed
+    // by Type. See the Condition* constants for the well-known types this
+    // operator sets.
+    // +patchMergeKey=type
+    // +patchStrategy=merge
+    // +listType=map
+    // +listMapKey=type
+    Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// Well-known MySQLStatus condition types.
+const (
+    // ConditionAvailable indicates the cluster's primary is reachable and serving.
+    ConditionAvailable = "Available"
+    // ConditionProgressing indicates the controller is actively working towards the desired state.
+    ConditionProgressing = "Progressing"
+    // ConditionDegraded indicates the last reconcile failed or the cluster is otherwise unhealthy.
+    ConditionDegraded = "Degraded"
+    // ConditionBackupSucceeded is True once a MySQLBackup for this cluster has
+    // completed and Unknown before any has; a later failed backup does not set it False.
+    ConditionBackupSucceeded = "BackupSucceeded"
+)
+
+// StatefulSetName returns the name of the StatefulSet backing this
+// cluster's MySQL workload.
+func (m *MySQL) StatefulSetName() string {
+    return fmt.Sprintf("mysql-%s", m.Name)
+}
+
+// HeadlessServiceName returns the name of the headless Service used for
+// stable per-pod DNS in HA topology modes.
+func (m *MySQL) HeadlessServiceName() string {
+    return fmt.Sprintf("%s-headless", m.StatefulSetName())
+}
+
+// PodHostname returns the stable, cluster-DNS-resolvable FQDN of the
+// ordinal-th pod in this cluster's StatefulSet, reachable via the headless
+// Service rather than the prefixed cluster name.
+func (m *MySQL) PodHostname(ordinal int32) string {
+    return fmt.Sprintf("%s-%d.%s.%s.svc", m.StatefulSetName(), ordinal, m.HeadlessServiceName(), m.Namespace)
+}
+
+// IsHA reports whether the cluster runs more than one coordinating replica
+// under the selected TopologyMode, rather than a single standalone instance.
+func (m *MySQL) IsHA() bool {
+    return m.Spec.Replicas != nil && *m.Spec.Replicas > 1
+}
+
+// EffectiveEngine returns the cluster's selected Engine, defaulting to
+// "mysql" so existing clusters that predate the Engine field keep working.
+func (m *MySQL) EffectiveEngine() string {
+    if m.Spec.Engine == "" {
+        return "mysql"
+    }
+    return m.Spec.Engine
+}
+
+// EffectiveEngineVersion returns the engine image tag to run, falling back
+// to MysqlVersion when EngineVersion is unset for backwards compatibility.
+func (m *MySQL) EffectiveEngineVersion() string {
+    if m.Spec.EngineVersion != "" {
+        return m.Spec.EngineVersion
+    }
+    return m.Spec.MysqlVersion
+}
+
+// SecretName returns the name of the Secret holding this cluster's
+// credentials: the referenced external Secret when SecretRef is set, or the
+// operator-owned Secret name otherwise.
+func (m *MySQL) SecretName() string {
+    if m.Spec.SecretRef != nil {
+        return m.Spec.SecretRef.Name
+    }
+    return fmt.Sprintf("mysql-secret-%s", m.Name)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// MySQL is the Schema for the mysqls API
+type MySQL struct {
+    metav1.TypeMeta   `json:",inline"`
+    metav1.ObjectMeta `json:"metadata,omitempty"`
+
+    Spec   MySQLSpec   `json:"spec,omitempty"`
+    Status MySQLStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MySQLList contains a list of MySQL
+type MySQLList struct {
+    metav1.TypeMeta `json:",inline"`
+    metav1.ListMeta `json:"metadata,omitempty"`
+    Items           []MySQL `json:"items"`
+}
\ No newline at end of file
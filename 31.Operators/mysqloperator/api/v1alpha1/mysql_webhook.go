@@ -0,0 +1,61 @@
+package v1alpha1
+
+import (
+    "context"
+    "fmt"
+
+    "k8s.io/apimachinery/pkg/runtime"
+    ctrl "sigs.k8s.io/controller-runtime"
+    "sigs.k8s.io/controller-runtime/pkg/webhook"
+    "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupWebhookWithManager registers the MySQL validating webhook with mgr.
+func (m *MySQL) SetupWebhookWithManager(mgr ctrl.Manager) error {
+    return ctrl.NewWebhookManagedBy(mgr).
+        For(m).
+        WithValidator(&MySQLValidator{}).
+        Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-operators-mysqloperator-io-v1alpha1-mysql,mutating=false,failurePolicy=fail,sideEffects=None,groups=operators.mysqloperator.io,resources=mysqls,verbs=update,versions=v1alpha1,name=vmysql.kb.io,admissionReviewVersions=v1
+
+// MySQLValidator validates MySQL admission requests. Only updates are
+// checked (see ValidateUpdate); creates and deletes are always admitted.
+// Kept as a separate type from MySQL itself per webhook.CustomValidator's
+// contract, which controller-runtime replaced the context-free
+// admission.Validator interface with.
+type MySQLValidator struct{}
+
+var _ webhook.CustomValidator = &MySQLValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *MySQLValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+    return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator. It rejects StorageSize
+// decreases, which PVCs cannot support once bound.
+func (v *MySQLValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+    oldMySQL, ok := oldObj.(*MySQL)
+    if !ok {
+        return nil, fmt.Errorf("expected a MySQL object but got %T", oldObj)
+    }
+    newMySQL, ok := newObj.(*MySQL)
+    if !ok {
+        return nil, fmt.Errorf("expected a MySQL object but got %T", newObj)
+    }
+
+    if newMySQL.Spec.StorageSize.Cmp(oldMySQL.Spec.StorageSize) < 0 {
+        return nil, fmt.Errorf(
+            "spec.storageSize cannot be decreased from %s to %s: PVCs cannot shrink",
+            oldMySQL.Spec.StorageSize.String(), newMySQL.Spec.StorageSize.String(),
+        )
+    }
+    return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *MySQLValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+    return nil, nil
+}
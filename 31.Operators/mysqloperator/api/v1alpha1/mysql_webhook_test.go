@@ -0,0 +1,41 @@
+package v1alpha1
+
+import (
+    "context"
+    "testing"
+
+    "k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestMySQLValidatorValidateUpdate(t *testing.T) {
+    v := &MySQLValidator{}
+
+    old := &MySQL{Spec: MySQLSpec{StorageSize: resource.MustParse("10Gi")}}
+
+    t.Run("grows", func(t *testing.T) {
+        newMySQL := &MySQL{Spec: MySQLSpec{StorageSize: resource.MustParse("20Gi")}}
+        if _, err := v.ValidateUpdate(context.Background(), old, newMySQL); err != nil {
+            t.Errorf("expected no error growing storageSize, got %v", err)
+        }
+    })
+
+    t.Run("unchanged", func(t *testing.T) {
+        newMySQL := &MySQL{Spec: MySQLSpec{StorageSize: resource.MustParse("10Gi")}}
+        if _, err := v.ValidateUpdate(context.Background(), old, newMySQL); err != nil {
+            t.Errorf("expected no error for an unchanged storageSize, got %v", err)
+        }
+    })
+
+    t.Run("shrinks", func(t *testing.T) {
+        newMySQL := &MySQL{Spec: MySQLSpec{StorageSize: resource.MustParse("5Gi")}}
+        if _, err := v.ValidateUpdate(context.Background(), old, newMySQL); err == nil {
+            t.Error("expected an error shrinking storageSize, got nil")
+        }
+    })
+
+    t.Run("wrong type", func(t *testing.T) {
+        if _, err := v.ValidateUpdate(context.Background(), old, &MySQLList{}); err == nil {
+            t.Error("expected an error when newObj is not a *MySQL, got nil")
+        }
+    })
+}
@@ -0,0 +1,98 @@
+package v1alpha1
+
+import (
+    corev1 "k8s.io/api/core/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StorageProvider identifies where a MySQLBackup artifact is written to.
+type StorageProvider struct {
+    // Type selects the backend: "s3", "gcs" or "pvc".
+    Type string `json:"type"`
+
+    // Bucket is the S3/GCS bucket name. Required when Type is "s3" or "gcs".
+    Bucket string `json:"bucket,omitempty"`
+
+    // Region is the S3 region. Only used when Type is "s3".
+    Region string `json:"region,omitempty"`
+
+    // Prefix is an optional key/object prefix under Bucket.
+    Prefix string `json:"prefix,omitempty"`
+
+    // ClaimName is the PVC to write into. Required when Type is "pvc".
+    ClaimName string `json:"claimName,omitempty"`
+}
+
+// MySQLBackupSpec defines the desired state of MySQLBackup
+type MySQLBackupSpec struct {
+    // ClusterRef is the name of the MySQL resource to back up.
+    ClusterRef string `json:"clusterRef"`
+
+    // StorageProvider configures where the backup artifact is uploaded.
+    StorageProvider StorageProvider `json:"storageProvider"`
+
+    // Credentials references a Secret holding the object store credentials
+    // (e.g. access key/secret key for S3/GCS). Not required for Type "pvc".
+    Credentials *corev1.LocalObjectReference `json:"credentials,omitempty"`
+}
+
+// BackupCondition describes a point-in-time state of a MySQLBackup.
+type BackupCondition struct {
+    Type               string      `json:"type"`
+    Status             string      `json:"status"`
+    Reason             string      `json:"reason,omitempty"`
+    Message            string      `json:"message,omitempty"`
+    LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// MySQLBackupStatus defines the observed state of MySQLBackup
+type MySQLBackupStatus struct {
+    Completed      bool              `json:"completed,omitempty"`
+    Conditions     []BackupCondition `json:"conditions,omitempty"`
+    StartTime      *metav1.Time      `json:"startTime,omitempty"`
+    CompletionTime *metav1.Time      `json:"completionTime,omitempty"`
+    BackupPath     string            `json:"backupPath,omitempty"`
+}
+
+// SetCondition upserts cond into s.Conditions by Type, mirroring
+// meta.SetStatusCondition's behavior for the metav1.Condition slice
+// MySQLStatus uses: an existing condition of the same Type is updated in
+// place, bumping LastTransitionTime only when Status actually changed,
+// instead of appended, so a Job stuck retrying doesn't grow Conditions
+// without bound.
+func (s *MySQLBackupStatus) SetCondition(cond BackupCondition) {
+    for i := range s.Conditions {
+        if s.Conditions[i].Type != cond.Type {
+            continue
+        }
+        if s.Conditions[i].Status != cond.Status {
+            s.Conditions[i].LastTransitionTime = cond.LastTransitionTime
+        }
+        s.Conditions[i].Status = cond.Status
+        s.Conditions[i].Reason = cond.Reason
+        s.Conditions[i].Message = cond.Message
+        return
+    }
+    s.Conditions = append(s.Conditions, cond)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// MySQLBackup is the Schema for the mysqlbackups API
+type MySQLBackup struct {
+    metav1.TypeMeta   `json:",inline"`
+    metav1.ObjectMeta `json:"metadata,omitempty"`
+
+    Spec   MySQLBackupSpec   `json:"spec,omitempty"`
+    Status MySQLBackupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MySQLBackupList contains a list of MySQLBackup
+type MySQLBackupList struct {
+    metav1.TypeMeta `json:",inline"`
+    metav1.ListMeta `json:"metadata,omitempty"`
+    Items           []MySQLBackup `json:"items"`
+}
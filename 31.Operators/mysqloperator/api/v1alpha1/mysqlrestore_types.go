@@ -0,0 +1,52 @@
+package v1alpha1
+
+import (
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RestoreAnnotation, set on a MySQL object, names the MySQLRestore whose
+// backup artifact reconcileStatefulSet should load via a one-shot init
+// container on the cluster's next rollout. MySQLRestoreReconciler is the
+// only writer of this annotation; MySQLReconciler is the only writer of
+// the StatefulSet spec that reads it, so the two controllers never race
+// over InitContainers. MySQLRestoreReconciler clears the annotation once
+// it observes the rollout it triggered complete.
+const RestoreAnnotation = "mysqloperator.io/restore-from"
+
+// MySQLRestoreSpec defines the desired state of MySQLRestore
+type MySQLRestoreSpec struct {
+    // ClusterRef is the name of the MySQL resource to restore into.
+    ClusterRef string `json:"clusterRef"`
+
+    // BackupRef is the name of the MySQLBackup whose artifact should be restored.
+    BackupRef string `json:"backupRef"`
+}
+
+// MySQLRestoreStatus defines the observed state of MySQLRestore
+type MySQLRestoreStatus struct {
+    Phase          string       `json:"phase,omitempty"`
+    Message        string       `json:"message,omitempty"`
+    StartTime      *metav1.Time `json:"startTime,omitempty"`
+    CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// MySQLRestore is the Schema for the mysqlrestores API
+type MySQLRestore struct {
+    metav1.TypeMeta   `json:",inline"`
+    metav1.ObjectMeta `json:"metadata,omitempty"`
+
+    Spec   MySQLRestoreSpec   `json:"spec,omitempty"`
+    Status MySQLRestoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MySQLRestoreList contains a list of MySQLRestore
+type MySQLRestoreList struct {
+    metav1.TypeMeta `json:",inline"`
+    metav1.ListMeta `json:"metadata,omitempty"`
+    Items           []MySQLRestore `json:"items"`
+}
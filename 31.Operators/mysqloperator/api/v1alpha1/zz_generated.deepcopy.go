@@ -0,0 +1,394 @@
+//go:build !ignore_autogenerated
+
+// Code generated by hand to stand in for controller-gen, which isn't
+// available in this build environment. Keep it in sync with the types in
+// this package: every +kubebuilder:object:root=true type must implement
+// runtime.Object via DeepCopyObject, and every field reachable from one
+// needs a DeepCopyInto that actually copies its pointers/slices/maps.
+
+package v1alpha1
+
+import (
+    corev1 "k8s.io/api/core/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLSpec) DeepCopyInto(out *MySQLSpec) {
+    *out = *in
+    if in.EngineConfig != nil {
+        in, out := &in.EngineConfig, &out.EngineConfig
+        *out = (*in).DeepCopy()
+    }
+    if in.SecretRef != nil {
+        in, out := &in.SecretRef, &out.SecretRef
+        *out = new(corev1.LocalObjectReference)
+        **out = **in
+    }
+    if in.GenerateSecret != nil {
+        in, out := &in.GenerateSecret, &out.GenerateSecret
+        *out = new(bool)
+        **out = **in
+    }
+    if in.Replicas != nil {
+        in, out := &in.Replicas, &out.Replicas
+        *out = new(int32)
+        **out = **in
+    }
+    out.StorageSize = in.StorageSize.DeepCopy()
+    if in.StorageClassName != nil {
+        in, out := &in.StorageClassName, &out.StorageClassName
+        *out = new(string)
+        **out = **in
+    }
+    in.Resources.DeepCopyInto(&out.Resources)
+    if in.NodeSelector != nil {
+        in, out := &in.NodeSelector, &out.NodeSelector
+        *out = make(map[string]string, len(*in))
+        for key, val := range *in {
+            (*out)[key] = val
+        }
+    }
+    if in.Tolerations != nil {
+        in, out := &in.Tolerations, &out.Tolerations
+        *out = make([]corev1.Toleration, len(*in))
+        for i := range *in {
+            (*in)[i].DeepCopyInto(&(*out)[i])
+        }
+    }
+    if in.Affinity != nil {
+        in, out := &in.Affinity, &out.Affinity
+        *out = new(corev1.Affinity)
+        (*in).DeepCopyInto(*out)
+    }
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new MySQLSpec.
+func (in *MySQLSpec) DeepCopy() *MySQLSpec {
+    if in == nil {
+        return nil
+    }
+    out := new(MySQLSpec)
+    in.DeepCopyInto(out)
+    return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLStatus) DeepCopyInto(out *MySQLStatus) {
+    *out = *in
+    if in.Conditions != nil {
+        in, out := &in.Conditions, &out.Conditions
+        *out = make([]metav1.Condition, len(*in))
+        for i := range *in {
+            (*in)[i].DeepCopyInto(&(*out)[i])
+        }
+    }
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new MySQLStatus.
+func (in *MySQLStatus) DeepCopy() *MySQLStatus {
+    if in == nil {
+        return nil
+    }
+    out := new(MySQLStatus)
+    in.DeepCopyInto(out)
+    return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQL) DeepCopyInto(out *MySQL) {
+    *out = *in
+    out.TypeMeta = in.TypeMeta
+    in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+    in.Spec.DeepCopyInto(&out.Spec)
+    in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new MySQL.
+func (in *MySQL) DeepCopy() *MySQL {
+    if in == nil {
+        return nil
+    }
+    out := new(MySQL)
+    in.DeepCopyInto(out)
+    return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MySQL) DeepCopyObject() runtime.Object {
+    if c := in.DeepCopy(); c != nil {
+        return c
+    }
+    return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLList) DeepCopyInto(out *MySQLList) {
+    *out = *in
+    out.TypeMeta = in.TypeMeta
+    in.ListMeta.DeepCopyInto(&out.ListMeta)
+    if in.Items != nil {
+        in, out := &in.Items, &out.Items
+        *out = make([]MySQL, len(*in))
+        for i := range *in {
+            (*in)[i].DeepCopyInto(&(*out)[i])
+        }
+    }
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new MySQLList.
+func (in *MySQLList) DeepCopy() *MySQLList {
+    if in == nil {
+        return nil
+    }
+    out := new(MySQLList)
+    in.DeepCopyInto(out)
+    return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MySQLList) DeepCopyObject() runtime.Object {
+    if c := in.DeepCopy(); c != nil {
+        return c
+    }
+    return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageProvider) DeepCopyInto(out *StorageProvider) {
+    *out = *in
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new StorageProvider.
+func (in *StorageProvider) DeepCopy() *StorageProvider {
+    if in == nil {
+        return nil
+    }
+    out := new(StorageProvider)
+    in.DeepCopyInto(out)
+    return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupCondition) DeepCopyInto(out *BackupCondition) {
+    *out = *in
+    in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new BackupCondition.
+func (in *BackupCondition) DeepCopy() *BackupCondition {
+    if in == nil {
+        return nil
+    }
+    out := new(BackupCondition)
+    in.DeepCopyInto(out)
+    return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLBackupSpec) DeepCopyInto(out *MySQLBackupSpec) {
+    *out = *in
+    out.StorageProvider = in.StorageProvider
+    if in.Credentials != nil {
+        in, out := &in.Credentials, &out.Credentials
+        *out = new(corev1.LocalObjectReference)
+        **out = **in
+    }
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new MySQLBackupSpec.
+func (in *MySQLBackupSpec) DeepCopy() *MySQLBackupSpec {
+    if in == nil {
+        return nil
+    }
+    out := new(MySQLBackupSpec)
+    in.DeepCopyInto(out)
+    return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLBackupStatus) DeepCopyInto(out *MySQLBackupStatus) {
+    *out = *in
+    if in.Conditions != nil {
+        in, out := &in.Conditions, &out.Conditions
+        *out = make([]BackupCondition, len(*in))
+        for i := range *in {
+            (*in)[i].DeepCopyInto(&(*out)[i])
+        }
+    }
+    if in.StartTime != nil {
+        in, out := &in.StartTime, &out.StartTime
+        *out = (*in).DeepCopy()
+    }
+    if in.CompletionTime != nil {
+        in, out := &in.CompletionTime, &out.CompletionTime
+        *out = (*in).DeepCopy()
+    }
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new MySQLBackupStatus.
+func (in *MySQLBackupStatus) DeepCopy() *MySQLBackupStatus {
+    if in == nil {
+        return nil
+    }
+    out := new(MySQLBackupStatus)
+    in.DeepCopyInto(out)
+    return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLBackup) DeepCopyInto(out *MySQLBackup) {
+    *out = *in
+    out.TypeMeta = in.TypeMeta
+    in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+    in.Spec.DeepCopyInto(&out.Spec)
+    in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new MySQLBackup.
+func (in *MySQLBackup) DeepCopy() *MySQLBackup {
+    if in == nil {
+        return nil
+    }
+    out := new(MySQLBackup)
+    in.DeepCopyInto(out)
+    return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MySQLBackup) DeepCopyObject() runtime.Object {
+    if c := in.DeepCopy(); c != nil {
+        return c
+    }
+    return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLBackupList) DeepCopyInto(out *MySQLBackupList) {
+    *out = *in
+    out.TypeMeta = in.TypeMeta
+    in.ListMeta.DeepCopyInto(&out.ListMeta)
+    if in.Items != nil {
+        in, out := &in.Items, &out.Items
+        *out = make([]MySQLBackup, len(*in))
+        for i := range *in {
+            (*in)[i].DeepCopyInto(&(*out)[i])
+        }
+    }
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new MySQLBackupList.
+func (in *MySQLBackupList) DeepCopy() *MySQLBackupList {
+    if in == nil {
+        return nil
+    }
+    out := new(MySQLBackupList)
+    in.DeepCopyInto(out)
+    return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MySQLBackupList) DeepCopyObject() runtime.Object {
+    if c := in.DeepCopy(); c != nil {
+        return c
+    }
+    return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLRestoreSpec) DeepCopyInto(out *MySQLRestoreSpec) {
+    *out = *in
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new MySQLRestoreSpec.
+func (in *MySQLRestoreSpec) DeepCopy() *MySQLRestoreSpec {
+    if in == nil {
+        return nil
+    }
+    out := new(MySQLRestoreSpec)
+    in.DeepCopyInto(out)
+    return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLRestoreStatus) DeepCopyInto(out *MySQLRestoreStatus) {
+    *out = *in
+    if in.StartTime != nil {
+        in, out := &in.StartTime, &out.StartTime
+        *out = (*in).DeepCopy()
+    }
+    if in.CompletionTime != nil {
+        in, out := &in.CompletionTime, &out.CompletionTime
+        *out = (*in).DeepCopy()
+    }
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new MySQLRestoreStatus.
+func (in *MySQLRestoreStatus) DeepCopy() *MySQLRestoreStatus {
+    if in == nil {
+        return nil
+    }
+    out := new(MySQLRestoreStatus)
+    in.DeepCopyInto(out)
+    return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLRestore) DeepCopyInto(out *MySQLRestore) {
+    *out = *in
+    out.TypeMeta = in.TypeMeta
+    in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+    in.Spec.DeepCopyInto(&out.Spec)
+    in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new MySQLRestore.
+func (in *MySQLRestore) DeepCopy() *MySQLRestore {
+    if in == nil {
+        return nil
+    }
+    out := new(MySQLRestore)
+    in.DeepCopyInto(out)
+    return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MySQLRestore) DeepCopyObject() runtime.Object {
+    if c := in.DeepCopy(); c != nil {
+        return c
+    }
+    return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLRestoreList) DeepCopyInto(out *MySQLRestoreList) {
+    *out = *in
+    out.TypeMeta = in.TypeMeta
+    in.ListMeta.DeepCopyInto(&out.ListMeta)
+    if in.Items != nil {
+        in, out := &in.Items, &out.Items
+        *out = make([]MySQLRestore, len(*in))
+        for i := range *in {
+            (*in)[i].DeepCopyInto(&(*out)[i])
+        }
+    }
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new MySQLRestoreList.
+func (in *MySQLRestoreList) DeepCopy() *MySQLRestoreList {
+    if in == nil {
+        return nil
+    }
+    out := new(MySQLRestoreList)
+    in.DeepCopyInto(out)
+    return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MySQLRestoreList) DeepCopyObject() runtime.Object {
+    if c := in.DeepCopy(); c != nil {
+        return c
+    }
+    return nil
+}
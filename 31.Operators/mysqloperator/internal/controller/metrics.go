@@ -0,0 +1,57 @@
+package controller
+
+import (
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "k8s.io/apimachinery/pkg/api/meta"
+    "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+    mysqlv1alpha1 "mysqloperator/api/v1alpha1"
+)
+
+var (
+    mysqlAvailable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+        Name: "mysql_available",
+        Help: "Whether the MySQL cluster's Available condition is True (1) or not (0).",
+    }, []string{"namespace", "name"})
+
+    mysqlHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+        Name: "mysql_healthy",
+        Help: "Whether the MySQL cluster's Degraded condition is False (1, healthy) or not (0).",
+    }, []string{"namespace", "name"})
+
+    mysqlBackupLastSuccessTimestampSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+        Name: "mysql_backup_last_success_timestamp_seconds",
+        Help: "Unix timestamp of the most recent successful MySQLBackup for this cluster.",
+    }, []string{"namespace", "name"})
+)
+
+func init() {
+    metrics.Registry.MustRegister(mysqlAvailable, mysqlHealthy, mysqlBackupLastSuccessTimestampSeconds)
+}
+
+// recordMetrics exports mysql's current Conditions and LastBackup onto the
+// controller-runtime Prometheus registry so it can be scraped for alerting.
+func recordMetrics(mysql *mysqlv1alpha1.MySQL) {
+    labels := prometheus.Labels{"namespace": mysql.Namespace, "name": mysql.Name}
+
+    mysqlAvailable.With(labels).Set(boolToFloat(meta.IsStatusConditionTrue(mysql.Status.Conditions, mysqlv1alpha1.ConditionAvailable)))
+    mysqlHealthy.With(labels).Set(boolToFloat(!meta.IsStatusConditionTrue(mysql.Status.Conditions, mysqlv1alpha1.ConditionDegraded)))
+
+    if mysql.Status.LastBackup == "" {
+        return
+    }
+    t, err := time.Parse(time.RFC3339, mysql.Status.LastBackup)
+    if err != nil {
+        return
+    }
+    mysqlBackupLastSuccessTimestampSeconds.With(labels).Set(float64(t.Unix()))
+}
+
+func boolToFloat(b bool) float64 {
+    if b {
+        return 1
+    }
+    return 0
+}
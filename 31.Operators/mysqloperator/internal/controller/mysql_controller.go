@@ -2,28 +2,39 @@ package controller
 
 import (
     "context"
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/hex"
     "fmt"
+    "sort"
+    "time"
 
     "k8s.io/apimachinery/pkg/api/errors"
+    "k8s.io/apimachinery/pkg/api/meta"
     "k8s.io/apimachinery/pkg/runtime"
     ctrl "sigs.k8s.io/controller-runtime"
     "sigs.k8s.io/controller-runtime/pkg/client"
     "sigs.k8s.io/controller-runtime/pkg/log"
+    "k8s.io/client-go/tools/record"
 
+    batchv1 "k8s.io/api/batch/v1"
     corev1 "k8s.io/api/core/v1"
     appsv1 "k8s.io/api/apps/v1"
     metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
     "k8s.io/apimachinery/pkg/util/intstr"
 
     mysqlv1alpha1 "mysqloperator/api/v1alpha1"
+    "mysqloperator/internal/engine"
 )
 
 type MySQLReconciler struct {
     client.Client
-    Scheme *runtime.Scheme
+    Scheme   *runtime.Scheme
+    Recorder record.EventRecorder
 }
 
-func (r *MySQLReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *MySQLReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, reconcileErr error) {
     log := log.FromContext(ctx)
 
     // Fetch the MySQL instance
@@ -37,94 +48,154 @@ func (r *MySQLReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
         return ctrl.Result{}, err
     }
 
-    // Reconcile MySQL deployment
-    if err := r.reconcileDeployment(ctx, mysql); err != nil {
-        return ctrl.Result{}, err
+    // Record status on every exit path, including partial-failure ones, so
+    // Conditions never go stale just because a step returned early.
+    defer func() {
+        if statusErr := r.updateReconcileStatus(ctx, mysql, reconcileErr); statusErr != nil {
+            log.Error(statusErr, "Failed to update MySQL status")
+        }
+    }()
+
+    // Reconcile MySQL secret first so both the workload and status probing
+    // below can rely on it already existing.
+    if reconcileErr = r.reconcileSecret(ctx, mysql); reconcileErr != nil {
+        return ctrl.Result{}, reconcileErr
+    }
+
+    // Reconcile MySQL workload: StatefulSet + headless Service + data PVC(s)
+    if reconcileErr = r.reconcileWorkload(ctx, mysql); reconcileErr != nil {
+        return ctrl.Result{}, reconcileErr
     }
 
     // Reconcile MySQL service
-    if err := r.reconcileService(ctx, mysql); err != nil {
-        return ctrl.Result{}, err
+    if reconcileErr = r.reconcileService(ctx, mysql); reconcileErr != nil {
+        return ctrl.Result{}, reconcileErr
     }
 
-    // Reconcile MySQL secret
-    if err := r.reconcileSecret(ctx, mysql); err != nil {
-        return ctrl.Result{}, err
+    // Reconcile scheduled backups, if requested
+    if mysql.Spec.BackupSchedule != "" {
+        if reconcileErr = r.reconcileBackupCronJob(ctx, mysql); reconcileErr != nil {
+            return ctrl.Result{}, reconcileErr
+        }
+        if reconcileErr = r.updateLastBackup(ctx, mysql); reconcileErr != nil {
+            return ctrl.Result{}, reconcileErr
+        }
+    }
+
+    // Bootstrap replication and update replication/availability status for HA topologies
+    if mysql.IsHA() {
+        if reconcileErr = r.reconcileReplication(ctx, mysql); reconcileErr != nil {
+            return ctrl.Result{}, reconcileErr
+        }
+        if reconcileErr = r.updateTopologyStatus(ctx, mysql); reconcileErr != nil {
+            return ctrl.Result{}, reconcileErr
+        }
     }
 
-    // Update status
     mysql.Status.Phase = "Ready"
     mysql.Status.Message = "MySQL instance is running"
-    if err := r.Status().Update(ctx, mysql); err != nil {
-        log.Error(err, "Failed to update MySQL status")
-        return ctrl.Result{}, err
+    return ctrl.Result{}, nil
+}
+
+// updateReconcileStatus records the outcome of this Reconcile call onto
+// mysql.Status.Conditions via meta.SetStatusCondition and persists it. It
+// runs from a defer so status reflects the true outcome even when Reconcile
+// returns early on a partial failure.
+func (r *MySQLReconciler) updateReconcileStatus(ctx context.Context, mysql *mysqlv1alpha1.MySQL, reconcileErr error) error {
+    // HA clusters get finer-grained Available/Degraded conditions from
+    // updateTopologyStatus's per-pod replication probes; don't clobber those
+    // with the generic reconcile-outcome verdict unless reconcile itself failed.
+    if reconcileErr != nil || !mysql.IsHA() {
+        available := reconcileErr == nil
+        meta.SetStatusCondition(&mysql.Status.Conditions, metav1.Condition{
+            Type:               mysqlv1alpha1.ConditionAvailable,
+            Status:             conditionStatus(available),
+            Reason:             conditionReason(available, "ReconcileSucceeded", "ReconcileFailed"),
+            Message:            reconcileMessage(reconcileErr, "MySQL instance is running"),
+            ObservedGeneration: mysql.Generation,
+        })
+        meta.SetStatusCondition(&mysql.Status.Conditions, metav1.Condition{
+            Type:               mysqlv1alpha1.ConditionDegraded,
+            Status:             conditionStatus(!available),
+            Reason:             conditionReason(!available, "ReconcileFailed", "ReconcileSucceeded"),
+            Message:            reconcileMessage(reconcileErr, ""),
+            ObservedGeneration: mysql.Generation,
+        })
     }
 
-    return ctrl.Result{}, nil
+    meta.SetStatusCondition(&mysql.Status.Conditions, metav1.Condition{
+        Type:               mysqlv1alpha1.ConditionProgressing,
+        Status:             metav1.ConditionFalse,
+        Reason:             "ReconcileComplete",
+        Message:            "reconcile loop finished",
+        ObservedGeneration: mysql.Generation,
+    })
+
+    if reconcileErr != nil {
+        mysql.Status.Phase = "Failed"
+        mysql.Status.Message = reconcileErr.Error()
+    }
+
+    recordMetrics(mysql)
+
+    return r.Status().Update(ctx, mysql)
+}
+
+// conditionStatus converts a bool into the metav1.Condition Status it maps to.
+func conditionStatus(b bool) metav1.ConditionStatus {
+    if b {
+        return metav1.ConditionTrue
+    }
+    return metav1.ConditionFalse
+}
+
+// conditionReason returns whenTrue if b is true, whenFalse otherwise.
+func conditionReason(b bool, whenTrue, whenFalse string) string {
+    if b {
+        return whenTrue
+    }
+    return whenFalse
+}
+
+// reconcileMessage returns err's message, or fallback when err is nil.
+func reconcileMessage(err error, fallback string) string {
+    if err != nil {
+        return err.Error()
+    }
+    return fallback
 }
 
-func (r *MySQLReconciler) reconcileDeployment(ctx context.Context, mysql *mysqlv1alpha1.MySQL) error {
+// reconcileBackupCronJob ensures a CronJob exists that creates a MySQLBackup
+// object for this cluster on the schedule given by Spec.BackupSchedule. The
+// actual dump/upload work is performed by MySQLBackupReconciler once the
+// MySQLBackup object it creates is picked up.
+func (r *MySQLReconciler) reconcileBackupCronJob(ctx context.Context, mysql *mysqlv1alpha1.MySQL) error {
     log := log.FromContext(ctx)
-    
-    deployment := &appsv1.Deployment{
+
+    cronJob := &batchv1.CronJob{
         ObjectMeta: metav1.ObjectMeta{
-            Name:      fmt.Sprintf("mysql-%s", mysql.Name),
+            Name:      fmt.Sprintf("mysql-backup-%s", mysql.Name),
             Namespace: mysql.Namespace,
         },
     }
 
-    _, err := ctrl.CreateOrUpdate(ctx, r.Client, deployment, func() error {
-        if deployment.Labels == nil {
-            deployment.Labels = make(map[string]string)
-        }
-        deployment.Labels["app"] = "mysql"
-        deployment.Labels["mysql-instance"] = mysql.Name
-        
-        replicas := int32(1)
-        deployment.Spec = appsv1.DeploymentSpec{
-            Replicas: &replicas,
-            Selector: &metav1.LabelSelector{
-                MatchLabels: map[string]string{
-                    "app": "mysql",
-                    "mysql-instance": mysql.Name,
-                },
-            },
-            Template: corev1.PodTemplateSpec{
-                ObjectMeta: metav1.ObjectMeta{
-                    Labels: map[string]string{
-                        "app": "mysql",
-                        "mysql-instance": mysql.Name,
-                    },
-                },
-                Spec: corev1.PodSpec{
-                    Containers: []corev1.Container{
-                        {
-                            Name:  "mysql",
-                            Image: fmt.Sprintf("mysql:%s", mysql.Spec.MysqlVersion),
-                            Env: []corev1.EnvVar{
-                                {
-                                    Name: "MYSQL_ROOT_PASSWORD",
-                                    ValueFrom: &corev1.EnvVarSource{
-                                        SecretKeyRef: &corev1.SecretKeySelector{
-                                            LocalObjectReference: corev1.LocalObjectReference{
-                                                Name: fmt.Sprintf("mysql-secret-%s", mysql.Name),
-                                            },
-                                            Key: "password",
-                                        },
-                                    },
-                                },
-                                {
-                                    Name:  "MYSQL_DATABASE",
-                                    Value: mysql.Spec.DatabaseName,
-                                },
-                                {
-                                    Name:  "MYSQL_USER",
-                                    Value: mysql.Spec.DatabaseUser,
-                                },
-                            },
-                            Ports: []corev1.ContainerPort{
+    _, err := ctrl.CreateOrUpdate(ctx, r.Client, cronJob, func() error {
+        cronJob.Spec = batchv1.CronJobSpec{
+            Schedule: mysql.Spec.BackupSchedule,
+            JobTemplate: batchv1.JobTemplateSpec{
+                Spec: batchv1.JobSpec{
+                    Template: corev1.PodTemplateSpec{
+                        Spec: corev1.PodSpec{
+                            RestartPolicy:      corev1.RestartPolicyOnFailure,
+                            ServiceAccountName: "mysql-operator-backup-creator",
+                            Containers: []corev1.Container{
                                 {
-                                    ContainerPort: 3306,
+                                    Name:  "create-mysqlbackup",
+                                    Image: "bitnami/kubectl:latest",
+                                    Command: []string{"/bin/sh", "-c"},
+                                    Args: []string{fmt.Sprintf(
+                                        "kubectl create -f - <<EOF\napiVersion: operators.mysqloperator.io/v1alpha1\nkind: MySQLBackup\nmetadata:\n  generateName: %s-\n  namespace: %s\nspec:\n  clusterRef: %s\n  storageProvider:\n    type: pvc\nEOF\n",
+                                        mysql.Name, mysql.Namespace, mysql.Name)},
                                 },
                             },
                         },
@@ -132,21 +203,71 @@ func (r *MySQLReconciler) reconcileDeployment(ctx context.Context, mysql *mysqlv
                 },
             },
         }
-        return ctrl.SetControllerReference(mysql, deployment, r.Scheme)
+        return ctrl.SetControllerReference(mysql, cronJob, r.Scheme)
     })
 
     if err != nil {
-        log.Error(err, "Failed to create/update Deployment")
+        log.Error(err, "Failed to create/update backup CronJob")
         return err
     }
-    
-    log.Info("Deployment successfully reconciled")
+
+    log.Info("Backup CronJob successfully reconciled")
+    return nil
+}
+
+// updateLastBackup records the completion time of the most recent successful
+// MySQLBackup for this cluster onto Status.LastBackup.
+func (r *MySQLReconciler) updateLastBackup(ctx context.Context, mysql *mysqlv1alpha1.MySQL) error {
+    backups := &mysqlv1alpha1.MySQLBackupList{}
+    if err := r.List(ctx, backups, client.InNamespace(mysql.Namespace)); err != nil {
+        return err
+    }
+
+    var latest *mysqlv1alpha1.MySQLBackup
+    for i := range backups.Items {
+        b := &backups.Items[i]
+        if b.Spec.ClusterRef != mysql.Name || !b.Status.Completed || b.Status.CompletionTime == nil {
+            continue
+        }
+        if latest == nil || b.Status.CompletionTime.After(latest.Status.CompletionTime.Time) {
+            latest = b
+        }
+    }
+
+    status := metav1.ConditionUnknown
+    reason := "NoBackupYet"
+    message := "no completed MySQLBackup found for this cluster yet"
+    if latest != nil {
+        mysql.Status.LastBackup = latest.Status.CompletionTime.Format(time.RFC3339)
+        status = metav1.ConditionTrue
+        reason = "BackupCompleted"
+        message = fmt.Sprintf("last successful backup: %s", latest.Name)
+    }
+    meta.SetStatusCondition(&mysql.Status.Conditions, metav1.Condition{
+        Type:               mysqlv1alpha1.ConditionBackupSucceeded,
+        Status:             status,
+        Reason:             reason,
+        Message:            message,
+        ObservedGeneration: mysql.Generation,
+    })
+
     return nil
 }
 
+// reconcileWorkload reconciles the StatefulSet + headless Service backing
+// the cluster, whether it's a single standalone instance or an HA topology.
+func (r *MySQLReconciler) reconcileWorkload(ctx context.Context, mysql *mysqlv1alpha1.MySQL) error {
+    return r.reconcileStatefulSet(ctx, mysql)
+}
+
 func (r *MySQLReconciler) reconcileService(ctx context.Context, mysql *mysqlv1alpha1.MySQL) error {
     log := log.FromContext(ctx)
-    
+
+    provider, err := engine.For(mysql.EffectiveEngine())
+    if err != nil {
+        return err
+    }
+
     service := &corev1.Service{
         ObjectMeta: metav1.ObjectMeta{
             Name:      fmt.Sprintf("mysql-%s", mysql.Name),
@@ -154,7 +275,7 @@ func (r *MySQLReconciler) reconcileService(ctx context.Context, mysql *mysqlv1al
         },
     }
 
-    _, err := ctrl.CreateOrUpdate(ctx, r.Client, service, func() error {
+    _, err = ctrl.CreateOrUpdate(ctx, r.Client, service, func() error {
         service.Spec = corev1.ServiceSpec{
             Selector: map[string]string{
                 "app": "mysql",
@@ -162,8 +283,8 @@ func (r *MySQLReconciler) reconcileService(ctx context.Context, mysql *mysqlv1al
             },
             Ports: []corev1.ServicePort{
                 {
-                    Port: 3306,
-                    TargetPort: intstr.FromInt(3306),
+                    Port:       provider.DefaultPort(),
+                    TargetPort: intstr.FromInt(int(provider.DefaultPort())),
                 },
             },
         }
@@ -181,7 +302,11 @@ func (r *MySQLReconciler) reconcileService(ctx context.Context, mysql *mysqlv1al
 
 func (r *MySQLReconciler) reconcileSecret(ctx context.Context, mysql *mysqlv1alpha1.MySQL) error {
     log := log.FromContext(ctx)
-    
+
+    if mysql.Spec.SecretRef != nil {
+        return r.validateExternalSecret(ctx, mysql)
+    }
+
     secret := &corev1.Secret{
         ObjectMeta: metav1.ObjectMeta{
             Name:      fmt.Sprintf("mysql-secret-%s", mysql.Name),
@@ -194,7 +319,14 @@ func (r *MySQLReconciler) reconcileSecret(ctx context.Context, mysql *mysqlv1alp
             secret.Data = make(map[string][]byte)
         }
         if _, exists := secret.Data["password"]; !exists {
-            secret.Data["password"] = []byte("mysql-operator-password-123")
+            if mysql.Spec.GenerateSecret != nil && !*mysql.Spec.GenerateSecret {
+                return fmt.Errorf("secret %q has no %q key and spec.generateSecret is false", secret.Name, "password")
+            }
+            password, err := generateRandomPassword()
+            if err != nil {
+                return fmt.Errorf("failed to generate MySQL password: %w", err)
+            }
+            secret.Data["password"] = password
         }
         return ctrl.SetControllerReference(mysql, secret, r.Scheme)
     })
@@ -203,16 +335,73 @@ func (r *MySQLReconciler) reconcileSecret(ctx context.Context, mysql *mysqlv1alp
         log.Error(err, "Failed to create/update Secret")
         return err
     }
-    
+
     log.Info("Secret successfully reconciled")
     return nil
 }
 
+// validateExternalSecret checks that the Secret referenced by Spec.SecretRef
+// carries the keys the StatefulSet needs. The operator never writes to this
+// Secret since it does not own it.
+func (r *MySQLReconciler) validateExternalSecret(ctx context.Context, mysql *mysqlv1alpha1.MySQL) error {
+    log := log.FromContext(ctx)
+
+    secret := &corev1.Secret{}
+    if err := r.Get(ctx, client.ObjectKey{Name: mysql.Spec.SecretRef.Name, Namespace: mysql.Namespace}, secret); err != nil {
+        log.Error(err, "Failed to get external Secret", "secretRef", mysql.Spec.SecretRef.Name)
+        return err
+    }
+    if _, ok := secret.Data["password"]; !ok {
+        return fmt.Errorf("secret %q referenced by spec.secretRef is missing required key %q", secret.Name, "password")
+    }
+
+    log.Info("External Secret validated", "secretRef", mysql.Spec.SecretRef.Name)
+    return nil
+}
+
+// secretNameFor returns the name of the Secret the workload should mount
+// credentials from: the externally managed one when SecretRef is set, or the
+// operator-owned one it creates in reconcileSecret.
+func secretNameFor(mysql *mysqlv1alpha1.MySQL) string {
+    return mysql.SecretName()
+}
+
+// generateRandomPassword returns a 32-byte cryptographically random password,
+// base64-encoded for safe use as an env var value.
+func generateRandomPassword() ([]byte, error) {
+    buf := make([]byte, 32)
+    if _, err := rand.Read(buf); err != nil {
+        return nil, err
+    }
+    encoded := base64.StdEncoding.EncodeToString(buf)
+    return []byte(encoded), nil
+}
+
+// secretDataHash deterministically hashes a Secret's data so it can be used
+// as a pod template annotation, forcing a rollout whenever the referenced
+// Secret's contents change.
+func secretDataHash(secret *corev1.Secret) string {
+    keys := make([]string, 0, len(secret.Data))
+    for k := range secret.Data {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+
+    h := sha256.New()
+    for _, k := range keys {
+        h.Write([]byte(k))
+        h.Write(secret.Data[k])
+    }
+    return hex.EncodeToString(h.Sum(nil))
+}
+
 func (r *MySQLReconciler) SetupWithManager(mgr ctrl.Manager) error {
+    r.Recorder = mgr.GetEventRecorderFor("mysql-controller")
     return ctrl.NewControllerManagedBy(mgr).
         For(&mysqlv1alpha1.MySQL{}).
-        Owns(&appsv1.Deployment{}).
+        Owns(&appsv1.StatefulSet{}).
         Owns(&corev1.Service{}).
         Owns(&corev1.Secret{}).
+        Owns(&batchv1.CronJob{}).
         Complete(r)
 }
\ No newline at end of file
@@ -0,0 +1,72 @@
+package controller
+
+import (
+    "encoding/base64"
+    "errors"
+    "testing"
+
+    corev1 "k8s.io/api/core/v1"
+)
+
+func TestGenerateRandomPassword(t *testing.T) {
+    a, err := generateRandomPassword()
+    if err != nil {
+        t.Fatalf("generateRandomPassword returned error: %v", err)
+    }
+    b, err := generateRandomPassword()
+    if err != nil {
+        t.Fatalf("generateRandomPassword returned error: %v", err)
+    }
+
+    if string(a) == string(b) {
+        t.Error("expected two calls to generateRandomPassword to differ")
+    }
+
+    decoded, err := base64.StdEncoding.DecodeString(string(a))
+    if err != nil {
+        t.Fatalf("password is not valid base64: %v", err)
+    }
+    if len(decoded) != 32 {
+        t.Errorf("decoded password length = %d, want 32", len(decoded))
+    }
+}
+
+func TestSecretDataHash(t *testing.T) {
+    s1 := &corev1.Secret{Data: map[string][]byte{"password": []byte("a"), "user-password": []byte("b")}}
+    s2 := &corev1.Secret{Data: map[string][]byte{"user-password": []byte("b"), "password": []byte("a")}}
+    s3 := &corev1.Secret{Data: map[string][]byte{"password": []byte("changed")}}
+
+    if secretDataHash(s1) != secretDataHash(s2) {
+        t.Error("expected key order not to affect the hash")
+    }
+    if secretDataHash(s1) == secretDataHash(s3) {
+        t.Error("expected different Secret data to produce different hashes")
+    }
+}
+
+func TestConditionStatus(t *testing.T) {
+    if got := conditionStatus(true); got != "True" {
+        t.Errorf("conditionStatus(true) = %q, want %q", got, "True")
+    }
+    if got := conditionStatus(false); got != "False" {
+        t.Errorf("conditionStatus(false) = %q, want %q", got, "False")
+    }
+}
+
+func TestConditionReason(t *testing.T) {
+    if got := conditionReason(true, "yes", "no"); got != "yes" {
+        t.Errorf("conditionReason(true, ...) = %q, want %q", got, "yes")
+    }
+    if got := conditionReason(false, "yes", "no"); got != "no" {
+        t.Errorf("conditionReason(false, ...) = %q, want %q", got, "no")
+    }
+}
+
+func TestReconcileMessage(t *testing.T) {
+    if got := reconcileMessage(errors.New("boom"), "fallback"); got != "boom" {
+        t.Errorf("reconcileMessage(err, ...) = %q, want %q", got, "boom")
+    }
+    if got := reconcileMessage(nil, "fallback"); got != "fallback" {
+        t.Errorf("reconcileMessage(nil, ...) = %q, want %q", got, "fallback")
+    }
+}
@@ -0,0 +1,409 @@
+package controller
+
+import (
+    "context"
+    "fmt"
+
+    appsv1 "k8s.io/api/apps/v1"
+    corev1 "k8s.io/api/core/v1"
+    storagev1 "k8s.io/api/storage/v1"
+    "k8s.io/apimachinery/pkg/api/errors"
+    "k8s.io/apimachinery/pkg/api/meta"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/util/intstr"
+    ctrl "sigs.k8s.io/controller-runtime"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+    "sigs.k8s.io/controller-runtime/pkg/log"
+
+    mysqlv1alpha1 "mysqloperator/api/v1alpha1"
+    "mysqloperator/internal/dbop"
+    "mysqloperator/internal/engine"
+)
+
+// reconcileStatefulSet reconciles the StatefulSet + headless Service backing
+// a cluster's workload. The engine container(s) come from the Provider
+// registered for mysql.Spec.Engine; this function only owns what's common
+// across engines: scheduling, secret rollout, and the data PVC.
+func (r *MySQLReconciler) reconcileStatefulSet(ctx context.Context, mysql *mysqlv1alpha1.MySQL) error {
+    log := log.FromContext(ctx)
+
+    if err := r.reconcileHeadlessService(ctx, mysql); err != nil {
+        return err
+    }
+
+    provider, err := engine.For(mysql.EffectiveEngine())
+    if err != nil {
+        log.Error(err, "Failed to resolve engine Provider")
+        return err
+    }
+
+    secret := &corev1.Secret{}
+    if err := r.Get(ctx, client.ObjectKey{Name: secretNameFor(mysql), Namespace: mysql.Namespace}, secret); err != nil {
+        log.Error(err, "Failed to get Secret for StatefulSet")
+        return err
+    }
+
+    sts := &appsv1.StatefulSet{
+        ObjectMeta: metav1.ObjectMeta{
+            Name:      mysql.StatefulSetName(),
+            Namespace: mysql.Namespace,
+        },
+    }
+
+    replicas := int32(1)
+    if mysql.Spec.Replicas != nil {
+        replicas = *mysql.Spec.Replicas
+    }
+
+    _, err = ctrl.CreateOrUpdate(ctx, r.Client, sts, func() error {
+        if sts.Labels == nil {
+            sts.Labels = make(map[string]string)
+        }
+        sts.Labels["app"] = "mysql"
+        sts.Labels["mysql-instance"] = mysql.Name
+
+        podSpec := provider.RenderPodSpec(mysql, secret)
+        podSpec.NodeSelector = mysql.Spec.NodeSelector
+        podSpec.Tolerations = mysql.Spec.Tolerations
+        podSpec.Affinity = mysql.Spec.Affinity
+        for i := range podSpec.Containers {
+            podSpec.Containers[i].Resources = mysql.Spec.Resources
+            podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts,
+                corev1.VolumeMount{Name: "data", MountPath: provider.DataMountPath()})
+            podSpec.Containers[i].ReadinessProbe = provider.HealthProbe()
+            podSpec.Containers[i].LivenessProbe = provider.HealthProbe()
+        }
+
+        if restoreName, ok := mysql.Annotations[mysqlv1alpha1.RestoreAnnotation]; ok {
+            restoreContainers, restoreVolumes, err := r.restoreInitContainersFor(ctx, mysql, restoreName)
+            if err != nil {
+                return err
+            }
+            podSpec.InitContainers = append(podSpec.InitContainers, restoreContainers...)
+            podSpec.Volumes = append(podSpec.Volumes, restoreVolumes...)
+        }
+
+        existingClaims := sts.Spec.VolumeClaimTemplates
+        sts.Spec = appsv1.StatefulSetSpec{
+            Replicas:    &replicas,
+            ServiceName: mysql.HeadlessServiceName(),
+            Selector: &metav1.LabelSelector{
+                MatchLabels: map[string]string{
+                    "app":            "mysql",
+                    "mysql-instance": mysql.Name,
+                },
+            },
+            Template: corev1.PodTemplateSpec{
+                ObjectMeta: metav1.ObjectMeta{
+                    Labels: map[string]string{
+                        "app":            "mysql",
+                        "mysql-instance": mysql.Name,
+                    },
+                    Annotations: map[string]string{
+                        "mysqloperator.io/secret-hash": secretDataHash(secret),
+                    },
+                },
+                Spec: podSpec,
+            },
+            VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+                {
+                    ObjectMeta: metav1.ObjectMeta{Name: "data"},
+                    Spec: corev1.PersistentVolumeClaimSpec{
+                        AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+                        StorageClassName: mysql.Spec.StorageClassName,
+                        Resources: corev1.VolumeResourceRequirements{
+                            Requests: corev1.ResourceList{
+                                corev1.ResourceStorage: mysql.Spec.StorageSize,
+                            },
+                        },
+                    },
+                },
+            },
+        }
+        // volumeClaimTemplates are immutable once created; keep the existing
+        // ones so a grown StorageSize is applied by reconcilePVCs instead.
+        if len(existingClaims) > 0 {
+            sts.Spec.VolumeClaimTemplates = existingClaims
+        }
+        return ctrl.SetControllerReference(mysql, sts, r.Scheme)
+    })
+
+    if err != nil {
+        log.Error(err, "Failed to create/update StatefulSet")
+        return err
+    }
+
+    log.Info("StatefulSet successfully reconciled")
+
+    if err := r.reconcilePVCs(ctx, mysql); err != nil {
+        return err
+    }
+
+    return nil
+}
+
+// restoreInitContainersFor looks up the MySQLRestore named by a MySQL
+// object's RestoreAnnotation and the MySQLBackup it targets, then builds the
+// init container(s) that load that backup's artifact. Called from
+// reconcileStatefulSet, which is the sole owner of the StatefulSet's pod
+// spec, so the restore init container is added and removed as part of the
+// same reconcile loop rather than a separate controller racing to patch it.
+func (r *MySQLReconciler) restoreInitContainersFor(ctx context.Context, mysql *mysqlv1alpha1.MySQL, restoreName string) ([]corev1.Container, []corev1.Volume, error) {
+    restore := &mysqlv1alpha1.MySQLRestore{}
+    if err := r.Get(ctx, client.ObjectKey{Name: restoreName, Namespace: mysql.Namespace}, restore); err != nil {
+        return nil, nil, err
+    }
+    backup := &mysqlv1alpha1.MySQLBackup{}
+    if err := r.Get(ctx, client.ObjectKey{Name: restore.Spec.BackupRef, Namespace: mysql.Namespace}, backup); err != nil {
+        return nil, nil, err
+    }
+    containers, volumes := restoreInitContainers(backup, mysql)
+    return containers, volumes, nil
+}
+
+// reconcilePVCs records the primary pod's data PVC name onto mysql.Status,
+// then reconciles every ordinal's data PVC: surfacing its bound/lost phase
+// as a Kubernetes Event, and expanding it in place when mysql.Spec.StorageSize
+// has grown and the bound StorageClass allows online expansion. StatefulSet
+// volumeClaimTemplates are immutable once created, so growing storage
+// requires patching each PVC directly.
+func (r *MySQLReconciler) reconcilePVCs(ctx context.Context, mysql *mysqlv1alpha1.MySQL) error {
+    replicas := int32(1)
+    if mysql.Spec.Replicas != nil {
+        replicas = *mysql.Spec.Replicas
+    }
+
+    mysql.Status.PVCName = fmt.Sprintf("data-%s-0", mysql.StatefulSetName())
+
+    for i := int32(0); i < replicas; i++ {
+        pvcName := fmt.Sprintf("data-%s-%d", mysql.StatefulSetName(), i)
+        if err := r.reconcilePVC(ctx, mysql, pvcName); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// reconcilePVC reconciles a single ordinal's data PVC: see reconcilePVCs.
+func (r *MySQLReconciler) reconcilePVC(ctx context.Context, mysql *mysqlv1alpha1.MySQL, pvcName string) error {
+    log := log.FromContext(ctx)
+
+    pvc := &corev1.PersistentVolumeClaim{}
+    if err := r.Get(ctx, client.ObjectKey{Name: pvcName, Namespace: mysql.Namespace}, pvc); err != nil {
+        if errors.IsNotFound(err) {
+            return nil
+        }
+        return err
+    }
+
+    switch pvc.Status.Phase {
+    case corev1.ClaimBound:
+        r.Recorder.Eventf(mysql, corev1.EventTypeNormal, "PVCBound", "PVC %s is bound", pvcName)
+    case corev1.ClaimLost:
+        r.Recorder.Eventf(mysql, corev1.EventTypeWarning, "PVCLost", "PVC %s has been lost", pvcName)
+    }
+
+    requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+    if mysql.Spec.StorageSize.Cmp(requested) <= 0 {
+        return nil
+    }
+
+    if pvc.Spec.StorageClassName != nil {
+        sc := &storagev1.StorageClass{}
+        if err := r.Get(ctx, client.ObjectKey{Name: *pvc.Spec.StorageClassName}, sc); err != nil {
+            log.Error(err, "Failed to get StorageClass for PVC expansion", "storageClass", *pvc.Spec.StorageClassName)
+            return err
+        }
+        if sc.AllowVolumeExpansion == nil || !*sc.AllowVolumeExpansion {
+            log.Info("StorageClass does not allow volume expansion, skipping", "storageClass", *pvc.Spec.StorageClassName)
+            return nil
+        }
+    }
+
+    pvc.Spec.Resources.Requests[corev1.ResourceStorage] = mysql.Spec.StorageSize
+    if err := r.Update(ctx, pvc); err != nil {
+        log.Error(err, "Failed to expand PVC", "pvc", pvcName)
+        return err
+    }
+    r.Recorder.Eventf(mysql, corev1.EventTypeNormal, "PVCExpanded", "PVC %s expanded to %s", pvcName, mysql.Spec.StorageSize.String())
+
+    return nil
+}
+
+// reconcileHeadlessService ensures the headless Service that gives each
+// StatefulSet pod a stable, individually resolvable DNS name exists.
+func (r *MySQLReconciler) reconcileHeadlessService(ctx context.Context, mysql *mysqlv1alpha1.MySQL) error {
+    log := log.FromContext(ctx)
+
+    provider, err := engine.For(mysql.EffectiveEngine())
+    if err != nil {
+        return err
+    }
+
+    svc := &corev1.Service{
+        ObjectMeta: metav1.ObjectMeta{
+            Name:      mysql.HeadlessServiceName(),
+            Namespace: mysql.Namespace,
+        },
+    }
+
+    _, err = ctrl.CreateOrUpdate(ctx, r.Client, svc, func() error {
+        svc.Spec = corev1.ServiceSpec{
+            ClusterIP: corev1.ClusterIPNone,
+            Selector: map[string]string{
+                "app":            "mysql",
+                "mysql-instance": mysql.Name,
+            },
+            Ports: []corev1.ServicePort{
+                {Port: provider.DefaultPort(), TargetPort: intstr.FromInt(int(provider.DefaultPort()))},
+            },
+        }
+        return ctrl.SetControllerReference(mysql, svc, r.Scheme)
+    })
+
+    if err != nil {
+        log.Error(err, "Failed to create/update headless Service")
+        return err
+    }
+
+    log.Info("Headless Service successfully reconciled")
+    return nil
+}
+
+// replicationUser is the MySQL/MariaDB user the operator grants REPLICATION
+// SLAVE and configures every non-primary pod to authenticate as.
+const replicationUser = "mysqloperator-repl"
+
+// reconcileReplication bootstraps source-replica replication for HA
+// topologies: it grants the replication user on the ordinal-0 pod, then
+// points every other ready pod at it via CHANGE REPLICATION SOURCE TO and
+// START REPLICA. It's a no-op for "standalone" topology and is safe to call
+// every reconcile, since CREATE USER IF NOT EXISTS and re-issuing CHANGE
+// REPLICATION SOURCE TO are both idempotent.
+func (r *MySQLReconciler) reconcileReplication(ctx context.Context, mysql *mysqlv1alpha1.MySQL) error {
+    log := log.FromContext(ctx)
+
+    if mysql.Spec.TopologyMode == "" || mysql.Spec.TopologyMode == "standalone" {
+        return nil
+    }
+
+    secret := &corev1.Secret{}
+    if err := r.Get(ctx, client.ObjectKey{Name: secretNameFor(mysql), Namespace: mysql.Namespace}, secret); err != nil {
+        return err
+    }
+    replPassword, ok := secret.Data["replication-password"]
+    if !ok {
+        log.Info("Secret has no replication-password key, skipping replication bootstrap")
+        return nil
+    }
+
+    sts := &appsv1.StatefulSet{}
+    if err := r.Get(ctx, client.ObjectKey{Name: mysql.StatefulSetName(), Namespace: mysql.Namespace}, sts); err != nil {
+        if errors.IsNotFound(err) {
+            return nil
+        }
+        return err
+    }
+    if sts.Status.ReadyReplicas == 0 {
+        return nil
+    }
+
+    primaryHost := mysql.PodHostname(0)
+    primary, err := dbop.New(primaryHost, string(secret.Data["password"]))
+    if err != nil {
+        log.Error(err, "Failed to connect to primary for replication bootstrap", "pod", primaryHost)
+        return nil
+    }
+    defer primary.Close()
+    if err := primary.EnsureReplicationUser(replicationUser, string(replPassword)); err != nil {
+        log.Error(err, "Failed to grant replication user on primary", "pod", primaryHost)
+        return err
+    }
+
+    for i := int32(1); i < sts.Status.ReadyReplicas; i++ {
+        host := mysql.PodHostname(i)
+        replica, err := dbop.New(host, string(secret.Data["password"]))
+        if err != nil {
+            log.Error(err, "Failed to connect to replica for replication bootstrap", "pod", host)
+            continue
+        }
+        if err := replica.ConfigureReplica(primaryHost, replicationUser, string(replPassword)); err != nil {
+            log.Error(err, "Failed to configure replica", "pod", host)
+        }
+        replica.Close()
+    }
+
+    return nil
+}
+
+// updateTopologyStatus probes each ready pod's replication role via dbop to
+// determine the cluster's primary, ready replica count, and health, then
+// writes the result onto mysql.Status (persisted by the caller).
+func (r *MySQLReconciler) updateTopologyStatus(ctx context.Context, mysql *mysqlv1alpha1.MySQL) error {
+    log := log.FromContext(ctx)
+
+    sts := &appsv1.StatefulSet{}
+    if err := r.Get(ctx, client.ObjectKey{Name: mysql.StatefulSetName(), Namespace: mysql.Namespace}, sts); err != nil {
+        if errors.IsNotFound(err) {
+            return nil
+        }
+        return err
+    }
+    mysql.Status.ReadyReplicas = sts.Status.ReadyReplicas
+
+    secret := &corev1.Secret{}
+    if err := r.Get(ctx, client.ObjectKey{Name: secretNameFor(mysql), Namespace: mysql.Namespace}, secret); err != nil {
+        return err
+    }
+
+    healthy := mysql.Status.ReadyReplicas > 0
+    mysql.Status.PrimaryPod = ""
+    for i := int32(0); i < mysql.Status.ReadyReplicas; i++ {
+        host := mysql.PodHostname(i)
+        probe, err := dbop.New(host, string(secret.Data["password"]))
+        if err != nil {
+            log.Error(err, "Failed to create dbop client", "pod", host)
+            healthy = false
+            continue
+        }
+
+        if !probe.Healthy() {
+            healthy = false
+            probe.Close()
+            continue
+        }
+
+        role, err := probe.Role()
+        if err != nil {
+            log.Error(err, "Failed to probe replication role", "pod", host)
+            healthy = false
+        } else if role == "primary" {
+            mysql.Status.PrimaryPod = fmt.Sprintf("%s-%d", mysql.StatefulSetName(), i)
+        }
+        probe.Close()
+    }
+
+    available := mysql.Status.PrimaryPod != ""
+
+    desiredReplicas := int32(1)
+    if mysql.Spec.Replicas != nil {
+        desiredReplicas = *mysql.Spec.Replicas
+    }
+
+    meta.SetStatusCondition(&mysql.Status.Conditions, metav1.Condition{
+        Type:               mysqlv1alpha1.ConditionAvailable,
+        Status:             conditionStatus(available),
+        Reason:             conditionReason(available, "PrimaryReachable", "PrimaryUnreachable"),
+        Message:            fmt.Sprintf("%d/%d replicas ready", mysql.Status.ReadyReplicas, desiredReplicas),
+        ObservedGeneration: mysql.Generation,
+    })
+    meta.SetStatusCondition(&mysql.Status.Conditions, metav1.Condition{
+        Type:               mysqlv1alpha1.ConditionDegraded,
+        Status:             conditionStatus(!healthy),
+        Reason:             conditionReason(!healthy, "ReplicationUnhealthy", "ReplicationHealthy"),
+        Message:            "computed from per-pod replication probes",
+        ObservedGeneration: mysql.Generation,
+    })
+
+    return nil
+}
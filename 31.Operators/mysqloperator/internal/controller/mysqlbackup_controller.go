@@ -0,0 +1,365 @@
+package controller
+
+import (
+    "context"
+    "fmt"
+
+    batchv1 "k8s.io/api/batch/v1"
+    corev1 "k8s.io/api/core/v1"
+    "k8s.io/apimachinery/pkg/api/errors"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/runtime"
+    ctrl "sigs.k8s.io/controller-runtime"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+    "sigs.k8s.io/controller-runtime/pkg/log"
+    "k8s.io/client-go/tools/record"
+
+    mysqlv1alpha1 "mysqloperator/api/v1alpha1"
+    "mysqloperator/internal/engine"
+)
+
+// MySQLBackupReconciler reconciles a MySQLBackup object
+type MySQLBackupReconciler struct {
+    client.Client
+    Scheme   *runtime.Scheme
+    Recorder record.EventRecorder
+}
+
+func (r *MySQLBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+    log := log.FromContext(ctx)
+
+    backup := &mysqlv1alpha1.MySQLBackup{}
+    if err := r.Get(ctx, req.NamespacedName, backup); err != nil {
+        if errors.IsNotFound(err) {
+            log.Info("MySQLBackup resource not found. Ignoring since object must be deleted")
+            return ctrl.Result{}, nil
+        }
+        log.Error(err, "Failed to get MySQLBackup")
+        return ctrl.Result{}, err
+    }
+
+    if backup.Status.Completed {
+        return ctrl.Result{}, nil
+    }
+
+    cluster := &mysqlv1alpha1.MySQL{}
+    if err := r.Get(ctx, client.ObjectKey{Namespace: backup.Namespace, Name: backup.Spec.ClusterRef}, cluster); err != nil {
+        log.Error(err, "Failed to get referenced MySQL cluster", "clusterRef", backup.Spec.ClusterRef)
+        return ctrl.Result{}, err
+    }
+
+    if err := r.reconcileBackupJob(ctx, backup, cluster); err != nil {
+        return ctrl.Result{}, err
+    }
+
+    return ctrl.Result{}, nil
+}
+
+func (r *MySQLBackupReconciler) reconcileBackupJob(ctx context.Context, backup *mysqlv1alpha1.MySQLBackup, cluster *mysqlv1alpha1.MySQL) error {
+    log := log.FromContext(ctx)
+
+    job := &batchv1.Job{
+        ObjectMeta: metav1.ObjectMeta{
+            Name:      fmt.Sprintf("mysql-backup-%s", backup.Name),
+            Namespace: backup.Namespace,
+        },
+    }
+
+    err := r.Get(ctx, client.ObjectKey{Name: job.Name, Namespace: job.Namespace}, job)
+    if err == nil {
+        r.syncStatusFromJob(ctx, backup, job)
+        return nil
+    }
+    if !errors.IsNotFound(err) {
+        log.Error(err, "Failed to get backup Job")
+        return err
+    }
+
+    provider, err := engine.For(cluster.EffectiveEngine())
+    if err != nil {
+        log.Error(err, "Failed to resolve engine Provider", "clusterRef", backup.Spec.ClusterRef)
+        return err
+    }
+    containers, initContainers, volumes := backupJobContainers(backup, cluster, provider)
+
+    job = &batchv1.Job{
+        ObjectMeta: metav1.ObjectMeta{
+            Name:      fmt.Sprintf("mysql-backup-%s", backup.Name),
+            Namespace: backup.Namespace,
+        },
+        Spec: batchv1.JobSpec{
+            Template: corev1.PodTemplateSpec{
+                Spec: corev1.PodSpec{
+                    RestartPolicy:  corev1.RestartPolicyNever,
+                    InitContainers: initContainers,
+                    Containers:     containers,
+                    Volumes:        volumes,
+                },
+            },
+        },
+    }
+    if err := ctrl.SetControllerReference(backup, job, r.Scheme); err != nil {
+        return err
+    }
+
+    if err := r.Create(ctx, job); err != nil {
+        log.Error(err, "Failed to create backup Job")
+        return err
+    }
+
+    now := metav1.Now()
+    backup.Status.StartTime = &now
+    if err := r.Status().Update(ctx, backup); err != nil {
+        log.Error(err, "Failed to update MySQLBackup status")
+        return err
+    }
+
+    log.Info("Backup Job created", "job", job.Name)
+    return nil
+}
+
+// backupArtifactVolume and backupArtifactMountPath are the emptyDir shared
+// between an s3/gcs backup Job's dump init container and its upload
+// container, since the engine image that can run mysqldump/mariabackup
+// doesn't ship an object-store CLI and vice versa.
+const (
+    backupArtifactVolume    = "backup-artifact"
+    backupArtifactMountPath = "/artifact"
+)
+
+// backupJobContainers builds the backup Job's pod spec pieces. "pvc"
+// destinations run a single container using mysql/mariadb's streaming
+// backup tool, which writes the artifact straight onto the mounted volume.
+// "s3"/"gcs" destinations split the work across two containers sharing an
+// emptyDir: an init container dumps the database using the engine's own
+// image, and the main container uploads the dump using an image that
+// actually ships aws/gsutil.
+func backupJobContainers(backup *mysqlv1alpha1.MySQLBackup, cluster *mysqlv1alpha1.MySQL, provider engine.Provider) (containers, initContainers []corev1.Container, volumes []corev1.Volume) {
+    passwordEnv := corev1.EnvVar{
+        Name: backupPasswordEnvVar(cluster.EffectiveEngine()),
+        ValueFrom: &corev1.EnvVarSource{
+            SecretKeyRef: &corev1.SecretKeySelector{
+                LocalObjectReference: corev1.LocalObjectReference{Name: cluster.SecretName()},
+                Key:                  "password",
+            },
+        },
+    }
+
+    if backup.Spec.StorageProvider.Type == "pvc" {
+        host := fmt.Sprintf("mysql-%s", cluster.Name)
+        cmd := provider.BackupCommand(cluster)
+        switch cluster.EffectiveEngine() {
+        case "mysql":
+            cmd = []string{"/bin/sh", "-c", fmt.Sprintf("xtrabackup --backup --host=%s --user=root --target-dir=/backup", host)}
+        case "mariadb":
+            cmd = []string{"/bin/sh", "-c", fmt.Sprintf("mariabackup --backup --host=%s --user=root --target-dir=/backup", host)}
+        }
+        return []corev1.Container{{Name: "backup", Image: backupImageFor(cluster), Command: cmd, Env: []corev1.EnvVar{passwordEnv}}}, nil, nil
+    }
+
+    uploadCmd := uploadCommandFor(backup)
+    if uploadCmd == "" {
+        dumpCmd := provider.BackupCommand(cluster)
+        return []corev1.Container{{Name: "backup", Image: backupImageFor(cluster), Command: dumpCmd, Env: []corev1.EnvVar{passwordEnv}}}, nil, nil
+    }
+
+    artifactMount := corev1.VolumeMount{Name: backupArtifactVolume, MountPath: backupArtifactMountPath}
+
+    // dumpCmd is always ["/bin/sh", "-c", "<script>"]; dump to the shared
+    // volume so the upload container has something to ship.
+    dumpCmd := provider.BackupCommand(cluster)
+    dumpScript := fmt.Sprintf("%s > %s/backup.dump", dumpCmd[2], backupArtifactMountPath)
+    dumpContainer := corev1.Container{
+        Name:         "dump",
+        Image:        backupImageFor(cluster),
+        Command:      []string{"/bin/sh", "-c", dumpScript},
+        Env:          []corev1.EnvVar{passwordEnv},
+        VolumeMounts: []corev1.VolumeMount{artifactMount},
+    }
+
+    uploadContainer := corev1.Container{
+        Name:         "upload",
+        Image:        uploadImageFor(backup.Spec.StorageProvider.Type),
+        Command:      []string{"/bin/sh", "-c", uploadCmd},
+        VolumeMounts: []corev1.VolumeMount{artifactMount},
+    }
+    if backup.Spec.Credentials != nil {
+        wireObjectStoreCredentials(&uploadContainer, &volumes, backup.Spec.StorageProvider.Type, backup.Spec.Credentials.Name)
+    }
+    volumes = append(volumes, corev1.Volume{
+        Name:         backupArtifactVolume,
+        VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+    })
+
+    return []corev1.Container{uploadContainer}, []corev1.Container{dumpContainer}, volumes
+}
+
+// backupArtifactKey is the object-store key a completed s3/gcs backup's
+// artifact is written under. MySQLRestore reads this same key back via
+// Status.BackupPath, so the upload step and the status it reports must
+// agree on it.
+func backupArtifactKey(backup *mysqlv1alpha1.MySQLBackup) string {
+    return fmt.Sprintf("%s/%s", backup.Spec.StorageProvider.Prefix, backup.Name)
+}
+
+// backupPathFor is what a completed backup's Status.BackupPath records:
+// the object-store key for s3/gcs, or the PVC target-dir the backup tool
+// wrote its artifact to for "pvc".
+func backupPathFor(backup *mysqlv1alpha1.MySQLBackup) string {
+    if backup.Spec.StorageProvider.Type == "pvc" {
+        return "/backup"
+    }
+    return backupArtifactKey(backup)
+}
+
+// uploadCommandFor returns the shell command that ships the shared dump
+// artifact to the MySQLBackup's configured object store, or "" for storage
+// types (e.g. "pvc") that don't upload anywhere.
+func uploadCommandFor(backup *mysqlv1alpha1.MySQLBackup) string {
+    src := fmt.Sprintf("%s/backup.dump", backupArtifactMountPath)
+    key := backupArtifactKey(backup)
+    switch backup.Spec.StorageProvider.Type {
+    case "s3":
+        return fmt.Sprintf("aws s3 cp %s s3://%s/%s", src, backup.Spec.StorageProvider.Bucket, key)
+    case "gcs":
+        return fmt.Sprintf("gsutil cp %s gs://%s/%s", src, backup.Spec.StorageProvider.Bucket, key)
+    default:
+        return ""
+    }
+}
+
+// uploadImageFor returns the image the backup Job's upload container should
+// run: one that actually ships the object store's CLI, since the engine
+// images backupImageFor returns don't.
+func uploadImageFor(storageType string) string {
+    switch storageType {
+    case "gcs":
+        return "google/cloud-sdk:475.0.0-slim"
+    default: // "s3"
+        return "amazon/aws-cli:2.15.53"
+    }
+}
+
+// wireObjectStoreCredentials grants the backup container access to the
+// object-store credentials Secret referenced by Spec.Credentials: s3 gets
+// the access/secret key pair as env vars the aws CLI reads natively, gcs
+// gets the service account key mounted as a file and GOOGLE_APPLICATION_CREDENTIALS
+// pointed at it for gsutil/ADC to pick up.
+func wireObjectStoreCredentials(container *corev1.Container, volumes *[]corev1.Volume, storageType, credentialsSecretName string) {
+    switch storageType {
+    case "s3":
+        container.Env = append(container.Env,
+            corev1.EnvVar{
+                Name: "AWS_ACCESS_KEY_ID",
+                ValueFrom: &corev1.EnvVarSource{
+                    SecretKeyRef: &corev1.SecretKeySelector{
+                        LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecretName},
+                        Key:                  "access-key-id",
+                    },
+                },
+            },
+            corev1.EnvVar{
+                Name: "AWS_SECRET_ACCESS_KEY",
+                ValueFrom: &corev1.EnvVarSource{
+                    SecretKeyRef: &corev1.SecretKeySelector{
+                        LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecretName},
+                        Key:                  "secret-access-key",
+                    },
+                },
+            },
+        )
+    case "gcs":
+        *volumes = append(*volumes, corev1.Volume{
+            Name: "gcs-credentials",
+            VolumeSource: corev1.VolumeSource{
+                Secret: &corev1.SecretVolumeSource{SecretName: credentialsSecretName},
+            },
+        })
+        container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+            Name:      "gcs-credentials",
+            MountPath: "/var/secrets/gcs",
+            ReadOnly:  true,
+        })
+        container.Env = append(container.Env, corev1.EnvVar{
+            Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+            Value: "/var/secrets/gcs/key.json",
+        })
+    }
+}
+
+// backupImageFor returns the image the backup Job's container should run,
+// matching the image family the cluster's own workload uses.
+func backupImageFor(cluster *mysqlv1alpha1.MySQL) string {
+    switch cluster.EffectiveEngine() {
+    case "mariadb":
+        return fmt.Sprintf("mariadb:%s", cluster.EffectiveEngineVersion())
+    case "postgres":
+        return fmt.Sprintf("postgres:%s", cluster.EffectiveEngineVersion())
+    case "mssql":
+        return fmt.Sprintf("mcr.microsoft.com/mssql/server:%s", cluster.EffectiveEngineVersion())
+    default:
+        return fmt.Sprintf("mysql:%s", cluster.EffectiveEngineVersion())
+    }
+}
+
+// backupPasswordEnvVar returns the env var name the backup Job's container
+// command expects the cluster's password under, which differs per engine.
+func backupPasswordEnvVar(engineName string) string {
+    switch engineName {
+    case "postgres":
+        return "PGPASSWORD"
+    case "mssql":
+        return "MSSQL_SA_PASSWORD"
+    default:
+        return "MYSQL_PWD"
+    }
+}
+
+// syncStatusFromJob records the backup Job's outcome onto backup.Status once
+// it has finished, whether it succeeded or failed. A still-running Job
+// (neither Succeeded nor Failed yet) leaves Status untouched.
+func (r *MySQLBackupReconciler) syncStatusFromJob(ctx context.Context, backup *mysqlv1alpha1.MySQLBackup, job *batchv1.Job) {
+    log := log.FromContext(ctx)
+
+    switch {
+    case job.Status.Succeeded > 0:
+        backup.Status.Completed = true
+        if job.Status.CompletionTime != nil {
+            backup.Status.CompletionTime = job.Status.CompletionTime
+        }
+        backup.Status.BackupPath = backupPathFor(backup)
+        backup.Status.SetCondition(mysqlv1alpha1.BackupCondition{
+            Type:               "Completed",
+            Status:             "True",
+            Reason:             "JobSucceeded",
+            Message:            "backup Job completed successfully",
+            LastTransitionTime: metav1.Now(),
+        })
+    case job.Status.Failed > 0:
+        backup.Status.Completed = false
+        backup.Status.SetCondition(mysqlv1alpha1.BackupCondition{
+            Type:               "Completed",
+            Status:             "False",
+            Reason:             "JobFailed",
+            Message:            fmt.Sprintf("backup Job %s failed", job.Name),
+            LastTransitionTime: metav1.Now(),
+        })
+        if r.Recorder != nil {
+            r.Recorder.Eventf(backup, corev1.EventTypeWarning, "BackupFailed", "backup Job %s failed", job.Name)
+        }
+    default:
+        return
+    }
+
+    if err := r.Status().Update(ctx, backup); err != nil {
+        log.Error(err, "Failed to update MySQLBackup status from Job")
+    }
+}
+
+func (r *MySQLBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+    r.Recorder = mgr.GetEventRecorderFor("mysqlbackup-controller")
+    return ctrl.NewControllerManagedBy(mgr).
+        For(&mysqlv1alpha1.MySQLBackup{}).
+        Owns(&batchv1.Job{}).
+        Complete(r)
+}
@@ -0,0 +1,237 @@
+package controller
+
+import (
+    "context"
+    "fmt"
+
+    appsv1 "k8s.io/api/apps/v1"
+    corev1 "k8s.io/api/core/v1"
+    "k8s.io/apimachinery/pkg/api/errors"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/runtime"
+    ctrl "sigs.k8s.io/controller-runtime"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+    "sigs.k8s.io/controller-runtime/pkg/log"
+
+    mysqlv1alpha1 "mysqloperator/api/v1alpha1"
+)
+
+// MySQLRestoreReconciler reconciles a MySQLRestore object
+type MySQLRestoreReconciler struct {
+    client.Client
+    Scheme *runtime.Scheme
+}
+
+func (r *MySQLRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+    log := log.FromContext(ctx)
+
+    restore := &mysqlv1alpha1.MySQLRestore{}
+    if err := r.Get(ctx, req.NamespacedName, restore); err != nil {
+        if errors.IsNotFound(err) {
+            log.Info("MySQLRestore resource not found. Ignoring since object must be deleted")
+            return ctrl.Result{}, nil
+        }
+        log.Error(err, "Failed to get MySQLRestore")
+        return ctrl.Result{}, err
+    }
+
+    if restore.Status.Phase == "Completed" {
+        return ctrl.Result{}, nil
+    }
+
+    backup := &mysqlv1alpha1.MySQLBackup{}
+    if err := r.Get(ctx, client.ObjectKey{Namespace: restore.Namespace, Name: restore.Spec.BackupRef}, backup); err != nil {
+        log.Error(err, "Failed to get referenced MySQLBackup", "backupRef", restore.Spec.BackupRef)
+        return ctrl.Result{}, err
+    }
+    if !backup.Status.Completed {
+        log.Info("Referenced MySQLBackup is not completed yet, requeuing", "backupRef", restore.Spec.BackupRef)
+        return ctrl.Result{Requeue: true}, nil
+    }
+
+    cluster := &mysqlv1alpha1.MySQL{}
+    if err := r.Get(ctx, client.ObjectKey{Namespace: restore.Namespace, Name: restore.Spec.ClusterRef}, cluster); err != nil {
+        log.Error(err, "Failed to get referenced MySQL cluster", "clusterRef", restore.Spec.ClusterRef)
+        return ctrl.Result{}, err
+    }
+
+    if restore.Status.Phase == "" {
+        return ctrl.Result{}, r.requestRestore(ctx, restore, cluster)
+    }
+
+    // Phase == "Pending": MySQLReconciler owns the StatefulSet spec, so it's
+    // the one that actually applies the restore init container we requested
+    // via RestoreAnnotation. Wait for its rollout to finish before declaring
+    // victory, instead of assuming success the moment we asked for it.
+    done, err := restoreRolloutComplete(ctx, r.Client, cluster)
+    if err != nil {
+        return ctrl.Result{}, err
+    }
+    if !done {
+        return ctrl.Result{Requeue: true}, nil
+    }
+
+    return ctrl.Result{}, r.finalizeRestore(ctx, restore, cluster)
+}
+
+// requestRestore hands the restore off to MySQLReconciler by annotating the
+// target MySQL object, rather than patching the StatefulSet directly: the
+// StatefulSet is owned and rebuilt every reconcile by MySQLReconciler, so
+// any InitContainers we set on it ourselves would be wiped on its very next
+// pass.
+func (r *MySQLRestoreReconciler) requestRestore(ctx context.Context, restore *mysqlv1alpha1.MySQLRestore, cluster *mysqlv1alpha1.MySQL) error {
+    log := log.FromContext(ctx)
+
+    if cluster.Annotations == nil {
+        cluster.Annotations = make(map[string]string)
+    }
+    cluster.Annotations[mysqlv1alpha1.RestoreAnnotation] = restore.Name
+    if err := r.Update(ctx, cluster); err != nil {
+        log.Error(err, "Failed to annotate target MySQL with pending restore")
+        return err
+    }
+
+    now := metav1.Now()
+    restore.Status.Phase = "Pending"
+    restore.Status.Message = fmt.Sprintf("requested restore init container on %s, waiting for rollout", cluster.StatefulSetName())
+    restore.Status.StartTime = &now
+    if err := r.Status().Update(ctx, restore); err != nil {
+        log.Error(err, "Failed to update MySQLRestore status")
+        return err
+    }
+
+    log.Info("MySQLRestore requested", "cluster", cluster.Name)
+    return nil
+}
+
+// restoreRolloutComplete reports whether the StatefulSet MySQLReconciler
+// rebuilt to include the restore init container has finished rolling out:
+// every desired replica is on the latest revision and ready.
+func restoreRolloutComplete(ctx context.Context, c client.Client, cluster *mysqlv1alpha1.MySQL) (bool, error) {
+    sts := &appsv1.StatefulSet{}
+    if err := c.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.StatefulSetName()}, sts); err != nil {
+        if errors.IsNotFound(err) {
+            return false, nil
+        }
+        return false, err
+    }
+
+    hasRestore := false
+    for _, c := range sts.Spec.Template.Spec.InitContainers {
+        if c.Name == "restore-load" {
+            hasRestore = true
+        }
+    }
+    if !hasRestore {
+        return false, nil
+    }
+
+    desired := int32(1)
+    if cluster.Spec.Replicas != nil {
+        desired = *cluster.Spec.Replicas
+    }
+
+    return sts.Status.ObservedGeneration == sts.Generation &&
+        sts.Status.UpdateRevision == sts.Status.CurrentRevision &&
+        sts.Status.ReadyReplicas == desired, nil
+}
+
+// finalizeRestore clears the restore annotation, so the next StatefulSet
+// reconcile drops the now-unneeded init container, and marks the
+// MySQLRestore Completed.
+func (r *MySQLRestoreReconciler) finalizeRestore(ctx context.Context, restore *mysqlv1alpha1.MySQLRestore, cluster *mysqlv1alpha1.MySQL) error {
+    log := log.FromContext(ctx)
+
+    delete(cluster.Annotations, mysqlv1alpha1.RestoreAnnotation)
+    if err := r.Update(ctx, cluster); err != nil {
+        log.Error(err, "Failed to clear restore annotation from target MySQL")
+        return err
+    }
+
+    now := metav1.Now()
+    restore.Status.Phase = "Completed"
+    restore.Status.Message = "restore init container applied and StatefulSet rollout completed"
+    restore.Status.CompletionTime = &now
+    if err := r.Status().Update(ctx, restore); err != nil {
+        log.Error(err, "Failed to update MySQLRestore status")
+        return err
+    }
+
+    log.Info("MySQLRestore reconciled", "cluster", cluster.Name)
+    return nil
+}
+
+// restoreInitContainers builds the init container(s) (and any volumes they
+// need) that load a backup artifact onto the target cluster before its
+// mysqld container starts. "s3"/"gcs" backups are fetched into a shared
+// emptyDir by an image that actually ships the matching CLI, since the
+// engine's own image doesn't; "pvc" backups are read directly off the PVC
+// the backup Job wrote them to.
+func restoreInitContainers(backup *mysqlv1alpha1.MySQLBackup, cluster *mysqlv1alpha1.MySQL) ([]corev1.Container, []corev1.Volume) {
+    loadImage := fmt.Sprintf("mysql:%s", cluster.EffectiveEngineVersion())
+
+    if backup.Spec.StorageProvider.Type == "pvc" {
+        artifactPath := fmt.Sprintf("/backup-source%s", backup.Status.BackupPath)
+        load := corev1.Container{
+            Name:    "restore-load",
+            Image:   loadImage,
+            Command: []string{"/bin/sh", "-c"},
+            Args:    []string{fmt.Sprintf("mysql --host=mysql-%s --user=root < %s", cluster.Name, artifactPath)},
+            VolumeMounts: []corev1.VolumeMount{
+                {Name: "backup-source", MountPath: "/backup-source", ReadOnly: true},
+            },
+        }
+        volumes := []corev1.Volume{
+            {
+                Name: "backup-source",
+                VolumeSource: corev1.VolumeSource{
+                    PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: backup.Spec.StorageProvider.ClaimName},
+                },
+            },
+        }
+        return []corev1.Container{load}, volumes
+    }
+
+    const artifactPath = "/artifact/restore.dump"
+    var volumes []corev1.Volume
+    fetch := corev1.Container{
+        Name:         "restore-fetch",
+        Image:        uploadImageFor(backup.Spec.StorageProvider.Type),
+        Command:      []string{"/bin/sh", "-c"},
+        Args:         []string{downloadCommandFor(backup, artifactPath)},
+        VolumeMounts: []corev1.VolumeMount{{Name: "restore-artifact", MountPath: "/artifact"}},
+    }
+    if backup.Spec.Credentials != nil {
+        wireObjectStoreCredentials(&fetch, &volumes, backup.Spec.StorageProvider.Type, backup.Spec.Credentials.Name)
+    }
+    volumes = append(volumes, corev1.Volume{Name: "restore-artifact", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}})
+
+    load := corev1.Container{
+        Name:         "restore-load",
+        Image:        loadImage,
+        Command:      []string{"/bin/sh", "-c"},
+        Args:         []string{fmt.Sprintf("mysql --host=mysql-%s --user=root < %s", cluster.Name, artifactPath)},
+        VolumeMounts: []corev1.VolumeMount{{Name: "restore-artifact", MountPath: "/artifact"}},
+    }
+
+    return []corev1.Container{fetch, load}, volumes
+}
+
+// downloadCommandFor returns the shell command that fetches backup's
+// artifact down to dest.
+func downloadCommandFor(backup *mysqlv1alpha1.MySQLBackup, dest string) string {
+    switch backup.Spec.StorageProvider.Type {
+    case "s3":
+        return fmt.Sprintf("aws s3 cp s3://%s/%s %s", backup.Spec.StorageProvider.Bucket, backup.Status.BackupPath, dest)
+    case "gcs":
+        return fmt.Sprintf("gsutil cp gs://%s/%s %s", backup.Spec.StorageProvider.Bucket, backup.Status.BackupPath, dest)
+    default:
+        return fmt.Sprintf("cp %s %s", backup.Status.BackupPath, dest)
+    }
+}
+
+func (r *MySQLRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+    return ctrl.NewControllerManagedBy(mgr).
+        For(&mysqlv1alpha1.MySQLRestore{}).
+        Complete(r)
+}
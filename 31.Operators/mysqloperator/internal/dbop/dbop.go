@@ -0,0 +1,109 @@
+// Package dbop provides a minimal client for probing a running MySQL
+// instance's replication state and bootstrapping replication on it. It is
+// intentionally small: reconcilers only need enough to classify a pod as
+// primary/replica, decide readiness, and set up a replication user and
+// source, not a general-purpose MySQL client.
+package dbop
+
+import (
+    "database/sql"
+    "fmt"
+    "strings"
+    "time"
+
+    _ "github.com/go-sql-driver/mysql"
+)
+
+// Client probes a single MySQL instance over TCP.
+type Client struct {
+    db *sql.DB
+}
+
+// New opens a lazy connection to the MySQL instance at host:3306,
+// authenticating as root with the given password.
+func New(host, rootPassword string) (*Client, error) {
+    dsn := fmt.Sprintf("root:%s@tcp(%s:3306)/?timeout=2s", rootPassword, host)
+    db, err := sql.Open("mysql", dsn)
+    if err != nil {
+        return nil, fmt.Errorf("dbop: open %s: %w", host, err)
+    }
+    db.SetConnMaxLifetime(5 * time.Second)
+    return &Client{db: db}, nil
+}
+
+// Role reports whether the instance is currently acting as a replication
+// source ("primary") or as a replica ("replica"), based on SHOW REPLICA
+// STATUS returning a row.
+func (c *Client) Role() (string, error) {
+    rows, err := c.db.Query("SHOW REPLICA STATUS")
+    if err != nil {
+        return "", fmt.Errorf("dbop: show replica status: %w", err)
+    }
+    defer rows.Close()
+
+    if rows.Next() {
+        return "replica", nil
+    }
+    return "primary", nil
+}
+
+// Healthy reports whether the instance responds to a ping.
+func (c *Client) Healthy() bool {
+    return c.db.Ping() == nil
+}
+
+// escapeSQLString escapes backslashes and single quotes so a value can be
+// safely interpolated inside a single-quoted MySQL string literal.
+// EnsureReplicationUser and ConfigureReplica build CREATE USER/CHANGE
+// REPLICATION SOURCE TO statements whose account/option literals aren't
+// bindable via the driver's placeholder support, so values coming from a
+// Secret (which may contain quotes or backslashes) are escaped here rather
+// than interpolated raw.
+func escapeSQLString(s string) string {
+    return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+}
+
+// EnsureReplicationUser creates (if it doesn't already exist) a user with the
+// REPLICATION SLAVE privilege, so a replica can authenticate as it when
+// subscribing to this instance as its replication source.
+func (c *Client) EnsureReplicationUser(username, password string) error {
+    user := escapeSQLString(username)
+    pass := escapeSQLString(password)
+
+    createStmt := fmt.Sprintf("CREATE USER IF NOT EXISTS '%s'@'%%' IDENTIFIED BY '%s'", user, pass)
+    if _, err := c.db.Exec(createStmt); err != nil {
+        return fmt.Errorf("dbop: create replication user: %w", err)
+    }
+    grantStmt := fmt.Sprintf("GRANT REPLICATION SLAVE ON *.* TO '%s'@'%%'", user)
+    if _, err := c.db.Exec(grantStmt); err != nil {
+        return fmt.Errorf("dbop: grant replication slave: %w", err)
+    }
+    return nil
+}
+
+// ConfigureReplica points this instance at sourceHost as its replication
+// source and starts replicating from it, authenticating as username. It uses
+// the MySQL 8 / MariaDB 10.5+ CHANGE REPLICATION SOURCE TO / START REPLICA
+// spelling rather than the deprecated CHANGE MASTER TO / START SLAVE.
+func (c *Client) ConfigureReplica(sourceHost, username, password string) error {
+    host := escapeSQLString(sourceHost)
+    user := escapeSQLString(username)
+    pass := escapeSQLString(password)
+
+    changeStmt := fmt.Sprintf(
+        "CHANGE REPLICATION SOURCE TO SOURCE_HOST='%s', SOURCE_PORT=3306, SOURCE_USER='%s', SOURCE_PASSWORD='%s', SOURCE_AUTO_POSITION=1",
+        host, user, pass,
+    )
+    if _, err := c.db.Exec(changeStmt); err != nil {
+        return fmt.Errorf("dbop: change replication source: %w", err)
+    }
+    if _, err := c.db.Exec("START REPLICA"); err != nil {
+        return fmt.Errorf("dbop: start replica: %w", err)
+    }
+    return nil
+}
+
+// Close releases the underlying connection pool.
+func (c *Client) Close() error {
+    return c.db.Close()
+}
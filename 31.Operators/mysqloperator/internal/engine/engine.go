@@ -0,0 +1,56 @@
+// Package engine abstracts the per-database-engine behavior the controller
+// needs, so a single MySQLReconciler can drive mysql, mariadb, postgres and
+// mssql clusters without duplicating reconcile logic per engine.
+package engine
+
+import (
+    "fmt"
+
+    corev1 "k8s.io/api/core/v1"
+
+    mysqlv1alpha1 "mysqloperator/api/v1alpha1"
+)
+
+// Provider renders the engine-specific parts of a cluster's workload. The
+// controller owns everything cross-cutting (scheduling, PVCs, Services,
+// Secrets); a Provider only knows how to run its own engine inside them.
+type Provider interface {
+    // RenderPodSpec builds the pod spec for the cluster's main workload,
+    // including any bootstrap init containers, the engine container itself,
+    // and volumes it privately needs. The caller adds scheduling fields
+    // (NodeSelector, Tolerations, Affinity) and the "data" PVC mount.
+    RenderPodSpec(mysql *mysqlv1alpha1.MySQL, secret *corev1.Secret) corev1.PodSpec
+
+    // DefaultPort is the engine's standard client port.
+    DefaultPort() int32
+
+    // HealthProbe returns the probe used for both readiness and liveness.
+    HealthProbe() *corev1.Probe
+
+    // BackupCommand returns the shell command a backup Job should run to
+    // dump this cluster to stdout (or a well-known local path).
+    BackupCommand(mysql *mysqlv1alpha1.MySQL) []string
+
+    // DataMountPath is where the engine expects its data directory mounted.
+    DataMountPath() string
+}
+
+var providers = map[string]Provider{
+    "mysql":    mysqlProvider{},
+    "mariadb":  mariaDBProvider{},
+    "postgres": postgresProvider{},
+    "mssql":    mssqlProvider{},
+}
+
+// For returns the Provider registered for the given engine name. An empty
+// name resolves to "mysql" to preserve the original mysql-only API.
+func For(name string) (Provider, error) {
+    if name == "" {
+        name = "mysql"
+    }
+    p, ok := providers[name]
+    if !ok {
+        return nil, fmt.Errorf("unsupported engine %q", name)
+    }
+    return p, nil
+}
@@ -0,0 +1,57 @@
+package engine
+
+import (
+    "fmt"
+
+    corev1 "k8s.io/api/core/v1"
+    "k8s.io/apimachinery/pkg/util/intstr"
+
+    mysqlv1alpha1 "mysqloperator/api/v1alpha1"
+)
+
+type mariaDBProvider struct{}
+
+func (mariaDBProvider) RenderPodSpec(mysql *mysqlv1alpha1.MySQL, secret *corev1.Secret) corev1.PodSpec {
+    return corev1.PodSpec{
+        InitContainers: []corev1.Container{serverIDInitContainer("mariadb", mysql.EffectiveEngineVersion())},
+        Containers: []corev1.Container{
+            {
+                Name:  "mariadb",
+                Image: fmt.Sprintf("mariadb:%s", mysql.EffectiveEngineVersion()),
+                Env:   mysqlStyleEnv(mysql, secret, "MARIADB"),
+                Ports: []corev1.ContainerPort{
+                    {ContainerPort: 3306},
+                },
+                VolumeMounts: []corev1.VolumeMount{
+                    {Name: "conf", MountPath: "/etc/mysql/conf.d"},
+                },
+            },
+        },
+        Volumes: []corev1.Volume{
+            {Name: "conf", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+        },
+    }
+}
+
+func (mariaDBProvider) DefaultPort() int32 {
+    return 3306
+}
+
+func (mariaDBProvider) HealthProbe() *corev1.Probe {
+    return &corev1.Probe{
+        ProbeHandler: corev1.ProbeHandler{
+            TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(3306)},
+        },
+    }
+}
+
+func (mariaDBProvider) BackupCommand(mysql *mysqlv1alpha1.MySQL) []string {
+    return []string{
+        "/bin/sh", "-c",
+        fmt.Sprintf("mariadb-dump -hmysql-%s -uroot -p\"$MYSQL_PWD\" %s", mysql.Name, mysql.Spec.DatabaseName),
+    }
+}
+
+func (mariaDBProvider) DataMountPath() string {
+    return "/var/lib/mysql"
+}
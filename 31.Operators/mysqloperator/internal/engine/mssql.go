@@ -0,0 +1,66 @@
+package engine
+
+import (
+    "fmt"
+
+    corev1 "k8s.io/api/core/v1"
+    "k8s.io/apimachinery/pkg/util/intstr"
+
+    mysqlv1alpha1 "mysqloperator/api/v1alpha1"
+)
+
+type mssqlProvider struct{}
+
+func (mssqlProvider) RenderPodSpec(mysql *mysqlv1alpha1.MySQL, secret *corev1.Secret) corev1.PodSpec {
+    env := []corev1.EnvVar{
+        {Name: "ACCEPT_EULA", Value: "Y"},
+        {
+            Name: "MSSQL_SA_PASSWORD",
+            ValueFrom: &corev1.EnvVarSource{
+                SecretKeyRef: &corev1.SecretKeySelector{
+                    LocalObjectReference: corev1.LocalObjectReference{Name: mysql.SecretName()},
+                    Key:                  "password",
+                },
+            },
+        },
+    }
+
+    return corev1.PodSpec{
+        Containers: []corev1.Container{
+            {
+                Name:  "mssql",
+                Image: fmt.Sprintf("mcr.microsoft.com/mssql/server:%s", mysql.EffectiveEngineVersion()),
+                Env:   env,
+                Ports: []corev1.ContainerPort{
+                    {ContainerPort: 1433},
+                },
+            },
+        },
+    }
+}
+
+func (mssqlProvider) DefaultPort() int32 {
+    return 1433
+}
+
+func (mssqlProvider) HealthProbe() *corev1.Probe {
+    return &corev1.Probe{
+        ProbeHandler: corev1.ProbeHandler{
+            TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(1433)},
+        },
+    }
+}
+
+func (mssqlProvider) BackupCommand(mysql *mysqlv1alpha1.MySQL) []string {
+    return []string{
+        "/bin/sh", "-c",
+        fmt.Sprintf(
+            `/opt/mssql-tools/bin/sqlcmd -S mysql-%s -U sa -P "$MSSQL_SA_PASSWORD" -Q "BACKUP DATABASE [%s] TO DISK = N'/var/opt/mssql/backup/%s.bak'"`,
+            mysql.Name, mysql.Spec.DatabaseName, mysql.Spec.DatabaseName,
+        ),
+    }
+}
+
+func (mssqlProvider) DataMountPath() string {
+    return "/var/opt/mssql"
+}
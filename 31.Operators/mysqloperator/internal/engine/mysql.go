@@ -0,0 +1,124 @@
+package engine
+
+import (
+    "fmt"
+
+    corev1 "k8s.io/api/core/v1"
+    "k8s.io/apimachinery/pkg/util/intstr"
+
+    mysqlv1alpha1 "mysqloperator/api/v1alpha1"
+)
+
+type mysqlProvider struct{}
+
+func (mysqlProvider) RenderPodSpec(mysql *mysqlv1alpha1.MySQL, secret *corev1.Secret) corev1.PodSpec {
+    return corev1.PodSpec{
+        InitContainers: []corev1.Container{serverIDInitContainer("mysql", mysql.EffectiveEngineVersion())},
+        Containers: []corev1.Container{
+            {
+                Name:  "mysql",
+                Image: fmt.Sprintf("mysql:%s", mysql.EffectiveEngineVersion()),
+                Env:   mysqlStyleEnv(mysql, secret, "MYSQL"),
+                Ports: []corev1.ContainerPort{
+                    {ContainerPort: 3306},
+                },
+                VolumeMounts: []corev1.VolumeMount{
+                    {Name: "conf", MountPath: "/etc/mysql/conf.d"},
+                },
+            },
+        },
+        Volumes: []corev1.Volume{
+            {Name: "conf", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+        },
+    }
+}
+
+func (mysqlProvider) DefaultPort() int32 {
+    return 3306
+}
+
+func (mysqlProvider) HealthProbe() *corev1.Probe {
+    return &corev1.Probe{
+        ProbeHandler: corev1.ProbeHandler{
+            TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(3306)},
+        },
+    }
+}
+
+func (mysqlProvider) BackupCommand(mysql *mysqlv1alpha1.MySQL) []string {
+    return []string{
+        "/bin/sh", "-c",
+        fmt.Sprintf("mysqldump -hmysql-%s -uroot -p\"$MYSQL_PWD\" %s", mysql.Name, mysql.Spec.DatabaseName),
+    }
+}
+
+func (mysqlProvider) DataMountPath() string {
+    return "/var/lib/mysql"
+}
+
+// serverIDInitContainer builds the init container that gives each StatefulSet
+// pod a unique, non-zero MySQL/MariaDB server-id derived from its ordinal,
+// shared by the mysql and mariadb providers since both use my.cnf conf.d.
+func serverIDInitContainer(image, version string) corev1.Container {
+    return corev1.Container{
+        Name:    "init-server-id",
+        Image:   fmt.Sprintf("%s:%s", image, version),
+        Command: []string{"/bin/sh", "-c"},
+        Args: []string{
+            `ordinal=$(hostname | grep -o '[0-9]*$'); ` +
+                `echo "[mysqld]" > /mnt/conf.d/server-id.cnf; ` +
+                `echo "server-id=$((100 + ordinal))" >> /mnt/conf.d/server-id.cnf`,
+        },
+        VolumeMounts: []corev1.VolumeMount{
+            {Name: "conf", MountPath: "/mnt/conf.d"},
+        },
+    }
+}
+
+// mysqlStyleEnv builds the <prefix>_ROOT_PASSWORD/_DATABASE/_USER family of
+// env vars that the official mysql and mariadb images both understand,
+// sourced from the cluster's Secret.
+func mysqlStyleEnv(mysql *mysqlv1alpha1.MySQL, secret *corev1.Secret, prefix string) []corev1.EnvVar {
+    env := []corev1.EnvVar{
+        {
+            Name: prefix + "_ROOT_PASSWORD",
+            ValueFrom: &corev1.EnvVarSource{
+                SecretKeyRef: &corev1.SecretKeySelector{
+                    LocalObjectReference: corev1.LocalObjectReference{Name: mysql.SecretName()},
+                    Key:                  "password",
+                },
+            },
+        },
+        {
+            Name:  prefix + "_DATABASE",
+            Value: mysql.Spec.DatabaseName,
+        },
+        {
+            Name:  prefix + "_USER",
+            Value: mysql.Spec.DatabaseUser,
+        },
+    }
+    if _, ok := secret.Data["user-password"]; ok {
+        env = append(env, corev1.EnvVar{
+            Name: prefix + "_PASSWORD",
+            ValueFrom: &corev1.EnvVarSource{
+                SecretKeyRef: &corev1.SecretKeySelector{
+                    LocalObjectReference: corev1.LocalObjectReference{Name: mysql.SecretName()},
+                    Key:                  "user-password",
+                },
+            },
+        })
+    }
+    if _, ok := secret.Data["replication-password"]; ok {
+        env = append(env, corev1.EnvVar{
+            Name: prefix + "_REPLICATION_PASSWORD",
+            ValueFrom: &corev1.EnvVarSource{
+                SecretKeyRef: &corev1.SecretKeySelector{
+                    LocalObjectReference: corev1.LocalObjectReference{Name: mysql.SecretName()},
+                    Key:                  "replication-password",
+                },
+            },
+        })
+    }
+    return env
+}
@@ -0,0 +1,64 @@
+package engine
+
+import (
+    "fmt"
+
+    corev1 "k8s.io/api/core/v1"
+    "k8s.io/apimachinery/pkg/util/intstr"
+
+    mysqlv1alpha1 "mysqloperator/api/v1alpha1"
+)
+
+type postgresProvider struct{}
+
+func (postgresProvider) RenderPodSpec(mysql *mysqlv1alpha1.MySQL, secret *corev1.Secret) corev1.PodSpec {
+    env := []corev1.EnvVar{
+        {
+            Name: "POSTGRES_PASSWORD",
+            ValueFrom: &corev1.EnvVarSource{
+                SecretKeyRef: &corev1.SecretKeySelector{
+                    LocalObjectReference: corev1.LocalObjectReference{Name: mysql.SecretName()},
+                    Key:                  "password",
+                },
+            },
+        },
+        {Name: "POSTGRES_DB", Value: mysql.Spec.DatabaseName},
+        {Name: "POSTGRES_USER", Value: mysql.Spec.DatabaseUser},
+    }
+
+    return corev1.PodSpec{
+        Containers: []corev1.Container{
+            {
+                Name:  "postgres",
+                Image: fmt.Sprintf("postgres:%s", mysql.EffectiveEngineVersion()),
+                Env:   env,
+                Ports: []corev1.ContainerPort{
+                    {ContainerPort: 5432},
+                },
+            },
+        },
+    }
+}
+
+func (postgresProvider) DefaultPort() int32 {
+    return 5432
+}
+
+func (postgresProvider) HealthProbe() *corev1.Probe {
+    return &corev1.Probe{
+        ProbeHandler: corev1.ProbeHandler{
+            TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(5432)},
+        },
+    }
+}
+
+func (postgresProvider) BackupCommand(mysql *mysqlv1alpha1.MySQL) []string {
+    return []string{
+        "/bin/sh", "-c",
+        fmt.Sprintf("pg_dump -hmysql-%s -U%s %s", mysql.Name, mysql.Spec.DatabaseUser, mysql.Spec.DatabaseName),
+    }
+}
+
+func (postgresProvider) DataMountPath() string {
+    return "/var/lib/postgresql/data"
+}